@@ -0,0 +1,33 @@
+/*
+Package drain provides the ability to cordon and drain Kubernetes nodes of an MKS cluster
+before they are deleted or reinstalled through the MKS V1 API.
+
+It's a separate module, so the k8s.io/client-go dependency is required only by its users.
+
+Example of reinstalling a single node of a cluster nodegroup after draining it
+
+	kubeConfig, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+	if err != nil {
+	  log.Fatal(err)
+	}
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+	  log.Fatal(err)
+	}
+	singleNode, _, err := node.Get(ctx, mksClient, clusterID, nodegroupID, nodeID)
+	if err != nil {
+	  log.Fatal(err)
+	}
+	drainer := drain.New(kubeClient, mksClient)
+	if err := drainer.ReinstallNode(ctx, clusterID, nodegroupID, singleNode); err != nil {
+	  log.Fatal(err)
+	}
+
+Example of deleting a single node of a cluster nodegroup after draining it
+
+	drainer := drain.New(kubeClient, mksClient)
+	if err := drainer.DeleteNode(ctx, clusterID, nodegroupID, singleNode); err != nil {
+	  log.Fatal(err)
+	}
+*/
+package drain
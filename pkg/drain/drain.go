@@ -0,0 +1,189 @@
+package drain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	v1 "github.com/selectel/mks-go/pkg/v1"
+)
+
+// DefaultPollInterval represents the default interval between Kubernetes and MKS API
+// requests when waiting for evictions and nodes.
+const DefaultPollInterval = 5 * time.Second
+
+// mirrorPodAnnotation marks static pods that can't be evicted through the API server.
+const mirrorPodAnnotation = "kubernetes.io/config.mirror"
+
+// ErrNodeNotFound is returned when a Kubernetes node of an MKS node can't be found.
+var ErrNodeNotFound = errors.New("kubernetes node not found")
+
+// Drainer cordons and drains Kubernetes nodes of an MKS cluster before they are
+// deleted or reinstalled with the MKS API.
+type Drainer struct {
+	// KubeClient is used to cordon nodes, evict pods and wait for nodes.
+	KubeClient kubernetes.Interface
+
+	// MKSClient is used to delete and reinstall nodes.
+	MKSClient *v1.ServiceClient
+
+	// GracePeriodSeconds overrides the termination grace period of evicted pods if it's set.
+	GracePeriodSeconds *int64
+
+	// PollInterval represents the interval between requests when evictions are blocked
+	// by PodDisruptionBudgets and when waiting for pods and nodes.
+	// DefaultPollInterval is used if it's not set.
+	PollInterval time.Duration
+}
+
+// New returns a Drainer that uses the provided Kubernetes and MKS clients.
+func New(kubeClient kubernetes.Interface, mksClient *v1.ServiceClient) *Drainer {
+	return &Drainer{
+		KubeClient: kubeClient,
+		MKSClient:  mksClient,
+	}
+}
+
+// Cordon marks a Kubernetes node referenced by its name as unschedulable.
+func (d *Drainer) Cordon(ctx context.Context, nodeName string) error {
+	return d.setUnschedulable(ctx, nodeName, true)
+}
+
+// Uncordon marks a Kubernetes node referenced by its name as schedulable.
+func (d *Drainer) Uncordon(ctx context.Context, nodeName string) error {
+	return d.setUnschedulable(ctx, nodeName, false)
+}
+
+func (d *Drainer) setUnschedulable(ctx context.Context, nodeName string, unschedulable bool) error {
+	patch := []byte(fmt.Sprintf(`{"spec":{"unschedulable":%t}}`, unschedulable))
+	_, err := d.KubeClient.CoreV1().Nodes().Patch(ctx, nodeName, types.MergePatchType, patch, metav1.PatchOptions{})
+
+	return err
+}
+
+// Drain cordons a Kubernetes node referenced by its name and evicts its pods.
+// DaemonSet, mirror and finished pods are skipped. Evictions use the Eviction API, so
+// PodDisruptionBudgets are respected: a blocked eviction is retried until it's allowed
+// or the context is done. Drain returns after all evicted pods are gone.
+func (d *Drainer) Drain(ctx context.Context, nodeName string) error {
+	if err := d.Cordon(ctx, nodeName); err != nil {
+		return err
+	}
+
+	pods, err := d.podsToEvict(ctx, nodeName)
+	if err != nil {
+		return err
+	}
+	for i := range pods {
+		if err := d.evict(ctx, &pods[i]); err != nil {
+			return fmt.Errorf("evict pod %s/%s: %w", pods[i].Namespace, pods[i].Name, err)
+		}
+	}
+	for i := range pods {
+		if err := d.waitForPodDeletion(ctx, &pods[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// podsToEvict returns pods of the node that should be evicted.
+func (d *Drainer) podsToEvict(ctx context.Context, nodeName string) ([]corev1.Pod, error) {
+	podList, err := d.KubeClient.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: "spec.nodeName=" + nodeName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pods := make([]corev1.Pod, 0, len(podList.Items))
+	for _, pod := range podList.Items {
+		if pod.Spec.NodeName == nodeName && isEvictable(&pod) {
+			pods = append(pods, pod)
+		}
+	}
+
+	return pods, nil
+}
+
+// isEvictable returns false for pods that are not moved by a drain.
+func isEvictable(pod *corev1.Pod) bool {
+	if _, ok := pod.Annotations[mirrorPodAnnotation]; ok {
+		return false
+	}
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return false
+	}
+	for _, owner := range pod.OwnerReferences {
+		if owner.Controller != nil && *owner.Controller && owner.Kind == "DaemonSet" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// evict requests an eviction of the pod and retries it while it's blocked
+// by a PodDisruptionBudget.
+func (d *Drainer) evict(ctx context.Context, pod *corev1.Pod) error {
+	eviction := &policyv1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+		},
+		DeleteOptions: &metav1.DeleteOptions{
+			GracePeriodSeconds: d.GracePeriodSeconds,
+		},
+	}
+
+	for {
+		err := d.KubeClient.CoreV1().Pods(pod.Namespace).EvictV1(ctx, eviction)
+		if err == nil || apierrors.IsNotFound(err) {
+			return nil
+		}
+		if !apierrors.IsTooManyRequests(err) {
+			return err
+		}
+
+		if err := v1.Sleep(ctx, d.pollInterval()); err != nil {
+			return err
+		}
+	}
+}
+
+// waitForPodDeletion waits until the pod is deleted or replaced by a pod with the same name.
+func (d *Drainer) waitForPodDeletion(ctx context.Context, pod *corev1.Pod) error {
+	for {
+		current, err := d.KubeClient.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if current.UID != pod.UID {
+			return nil
+		}
+
+		if err := v1.Sleep(ctx, d.pollInterval()); err != nil {
+			return err
+		}
+	}
+}
+
+func (d *Drainer) pollInterval() time.Duration {
+	if d.PollInterval <= 0 {
+		return DefaultPollInterval
+	}
+
+	return d.PollInterval
+}
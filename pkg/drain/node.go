@@ -0,0 +1,185 @@
+package drain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/selectel/mks-go/pkg/v1"
+	"github.com/selectel/mks-go/pkg/v1/node"
+	"github.com/selectel/mks-go/pkg/v1/nodegroup"
+)
+
+// hostnameLabel contains the hostname of a Kubernetes node.
+const hostnameLabel = "kubernetes.io/hostname"
+
+// FindNode returns the Kubernetes node of an MKS node. The node is looked up by the MKS node
+// hostname, then by the hostname label and the internal IP address.
+// ErrNodeNotFound is returned if there is no such node.
+func (d *Drainer) FindNode(ctx context.Context, mksNode *node.View) (*corev1.Node, error) {
+	if mksNode.Hostname != "" {
+		k8sNode, err := d.KubeClient.CoreV1().Nodes().Get(ctx, mksNode.Hostname, metav1.GetOptions{})
+		if err == nil {
+			return k8sNode, nil
+		}
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+
+	nodeList, err := d.KubeClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range nodeList.Items {
+		if matchesNode(&nodeList.Items[i], mksNode) {
+			return &nodeList.Items[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrNodeNotFound, mksNode.Hostname)
+}
+
+// matchesNode returns true if the Kubernetes node has the MKS node hostname label or IP address.
+func matchesNode(k8sNode *corev1.Node, mksNode *node.View) bool {
+	if mksNode.Hostname != "" && k8sNode.Labels[hostnameLabel] == mksNode.Hostname {
+		return true
+	}
+	for _, address := range k8sNode.Status.Addresses {
+		if mksNode.IP != "" && address.Type == corev1.NodeInternalIP && address.Address == mksNode.IP {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DeleteNode drains the Kubernetes node of an MKS node and deletes the MKS node.
+// It waits until a replacement node appears in the nodegroup and its Kubernetes node is Ready.
+func (d *Drainer) DeleteNode(ctx context.Context, clusterID, nodegroupID string, mksNode *node.View) error {
+	k8sNode, err := d.FindNode(ctx, mksNode)
+	if err != nil {
+		return err
+	}
+	if err := d.Drain(ctx, k8sNode.Name); err != nil {
+		return err
+	}
+
+	mksNodegroup, _, err := nodegroup.Get(ctx, d.MKSClient, clusterID, nodegroupID)
+	if err != nil {
+		return err
+	}
+	if _, err := node.Delete(ctx, d.MKSClient, clusterID, nodegroupID, mksNode.ID); err != nil {
+		return err
+	}
+
+	replacement, err := d.waitForReplacement(ctx, clusterID, nodegroupID, mksNodegroup.Nodes)
+	if err != nil {
+		return err
+	}
+
+	return d.waitForReady(ctx, replacement, nil)
+}
+
+// ReinstallNode drains the Kubernetes node of an MKS node and reinstalls the MKS node.
+// It waits until the Kubernetes node becomes Ready after the reinstall and uncordons it.
+func (d *Drainer) ReinstallNode(ctx context.Context, clusterID, nodegroupID string, mksNode *node.View) error {
+	k8sNode, err := d.FindNode(ctx, mksNode)
+	if err != nil {
+		return err
+	}
+	if err := d.Drain(ctx, k8sNode.Name); err != nil {
+		return err
+	}
+
+	if _, err := node.Reinstall(ctx, d.MKSClient, clusterID, nodegroupID, mksNode.ID); err != nil {
+		return err
+	}
+	if err := d.waitForReady(ctx, mksNode, k8sNode); err != nil {
+		return err
+	}
+
+	k8sNode, err = d.FindNode(ctx, mksNode)
+	if err != nil {
+		return err
+	}
+
+	return d.Uncordon(ctx, k8sNode.Name)
+}
+
+// waitForReplacement waits until a node that is absent in the previous nodes list
+// appears in the nodegroup with its hostname and returns it.
+func (d *Drainer) waitForReplacement(ctx context.Context, clusterID, nodegroupID string,
+	previous []*node.View,
+) (*node.View, error) {
+	known := make(map[string]struct{}, len(previous))
+	for _, n := range previous {
+		known[n.ID] = struct{}{}
+	}
+
+	for {
+		mksNodegroup, _, err := nodegroup.Get(ctx, d.MKSClient, clusterID, nodegroupID)
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range mksNodegroup.Nodes {
+			if _, ok := known[n.ID]; !ok && n.Hostname != "" {
+				return n, nil
+			}
+		}
+
+		if err := v1.Sleep(ctx, d.pollInterval()); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// waitForReady waits until the Kubernetes node of an MKS node is Ready. If the previous
+// state of the Kubernetes node is provided, the node also has to be recreated or its Ready
+// condition has to have changed since that state. Only timestamps of the Kubernetes API
+// are compared, so the local clock doesn't affect the check.
+func (d *Drainer) waitForReady(ctx context.Context, mksNode *node.View, previous *corev1.Node) error {
+	for {
+		k8sNode, err := d.FindNode(ctx, mksNode)
+		if err != nil && !errors.Is(err, ErrNodeNotFound) {
+			return err
+		}
+		if k8sNode != nil && isReadyAfter(k8sNode, previous) {
+			return nil
+		}
+
+		if err := v1.Sleep(ctx, d.pollInterval()); err != nil {
+			return err
+		}
+	}
+}
+
+// isReadyAfter returns true if the node is Ready and, if the previous state is provided,
+// the node has been recreated or its Ready condition has changed after the previous state.
+func isReadyAfter(k8sNode, previous *corev1.Node) bool {
+	ready := readyCondition(k8sNode)
+	if ready == nil || ready.Status != corev1.ConditionTrue {
+		return false
+	}
+	if previous == nil || k8sNode.UID != previous.UID {
+		return true
+	}
+	previousReady := readyCondition(previous)
+
+	return previousReady == nil || ready.LastTransitionTime.After(previousReady.LastTransitionTime.Time)
+}
+
+// readyCondition returns the Ready condition of the node or nil if it's absent.
+func readyCondition(k8sNode *corev1.Node) *corev1.NodeCondition {
+	for i := range k8sNode.Status.Conditions {
+		if k8sNode.Status.Conditions[i].Type == corev1.NodeReady {
+			return &k8sNode.Status.Conditions[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,275 @@
+package testing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/selectel/mks-go/pkg/drain"
+	"github.com/selectel/mks-go/pkg/testutils"
+	v1 "github.com/selectel/mks-go/pkg/v1"
+	"github.com/selectel/mks-go/pkg/v1/node"
+)
+
+const (
+	clusterID   = "e172551c-3700-49fa-af0e-7f547bce788a"
+	nodegroupID = "c476d45a-0bcc-e13d-b418-180d059d79cd"
+	nodeID      = "3d5d0e6a-1e2b-4c9c-9b0b-2f1e3b4a5c6d"
+	hostname    = "test-cluster-node-0"
+)
+
+var testMKSNode = &node.View{
+	ID:          nodeID,
+	Hostname:    hostname,
+	IP:          "10.0.0.10",
+	NodegroupID: nodegroupID,
+}
+
+func testPod(name, nodeName string, modify func(pod *corev1.Pod)) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			UID:       types.UID("uid-" + name),
+		},
+		Spec: corev1.PodSpec{NodeName: nodeName},
+	}
+	if modify != nil {
+		modify(pod)
+	}
+
+	return pod
+}
+
+func readyNode(name string, transitionTime time.Time) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{"kubernetes.io/hostname": name},
+		},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{
+					Type:               corev1.NodeReady,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(transitionTime),
+				},
+			},
+		},
+	}
+}
+
+// newTestKubeClient returns a fake clientset with the node and its pods. Evictions delete
+// pods unless they are blocked by the provided number of PodDisruptionBudget rejections.
+func newTestKubeClient(blockedEvictions int) (*fake.Clientset, *[]string) {
+	isController := true
+	kubeClient := fake.NewSimpleClientset(
+		readyNode(hostname, time.Now().Add(-time.Hour)),
+		testPod("app", hostname, nil),
+		testPod("other-node-app", "other-node", nil),
+		testPod("daemon", hostname, func(pod *corev1.Pod) {
+			pod.OwnerReferences = []metav1.OwnerReference{{Kind: "DaemonSet", Name: "daemon", Controller: &isController}}
+		}),
+		testPod("static", hostname, func(pod *corev1.Pod) {
+			pod.Annotations = map[string]string{"kubernetes.io/config.mirror": "hash"}
+		}),
+		testPod("completed", hostname, func(pod *corev1.Pod) {
+			pod.Status.Phase = corev1.PodSucceeded
+		}),
+	)
+
+	var (
+		mu      sync.Mutex
+		evicted []string
+	)
+	kubeClient.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		eviction := action.(k8stesting.CreateAction).GetObject().(*policyv1.Eviction)
+
+		mu.Lock()
+		defer mu.Unlock()
+		if blockedEvictions > 0 {
+			blockedEvictions--
+
+			return true, nil, apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+		}
+		evicted = append(evicted, eviction.Name)
+		podsResource := corev1.SchemeGroupVersion.WithResource("pods")
+
+		return true, nil, kubeClient.Tracker().Delete(podsResource, eviction.Namespace, eviction.Name)
+	})
+
+	return kubeClient, &evicted
+}
+
+func newTestMKSClient(testEnv *testutils.TestEnv) *v1.ServiceClient {
+	return &v1.ServiceClient{
+		HTTPClient: &http.Client{},
+		TokenID:    testutils.TokenID,
+		Endpoint:   testEnv.Server.URL + "/v1",
+		UserAgent:  testutils.UserAgent,
+	}
+}
+
+func assertDrained(ctx context.Context, t *testing.T, kubeClient *fake.Clientset, evicted []string) {
+	t.Helper()
+
+	if expected := []string{"app"}; !reflect.DeepEqual(expected, evicted) {
+		t.Fatalf("expected %v pods to be evicted, but got %v", expected, evicted)
+	}
+	for _, name := range []string{"other-node-app", "daemon", "static", "completed"} {
+		if _, err := kubeClient.CoreV1().Pods("default").Get(ctx, name, metav1.GetOptions{}); err != nil {
+			t.Fatalf("expected %s pod to be kept, but got %v", name, err)
+		}
+	}
+}
+
+func TestDrain(t *testing.T) {
+	ctx := context.Background()
+	kubeClient, evicted := newTestKubeClient(2)
+	drainer := drain.New(kubeClient, nil)
+	drainer.PollInterval = time.Millisecond
+
+	if err := drainer.Drain(ctx, hostname); err != nil {
+		t.Fatal(err)
+	}
+
+	k8sNode, err := kubeClient.CoreV1().Nodes().Get(ctx, hostname, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !k8sNode.Spec.Unschedulable {
+		t.Fatal("expected the node to be cordoned")
+	}
+	assertDrained(ctx, t, kubeClient, *evicted)
+}
+
+func TestDrainBlockedByPodDisruptionBudget(t *testing.T) {
+	kubeClient, evicted := newTestKubeClient(1000)
+	drainer := drain.New(kubeClient, nil)
+	drainer.PollInterval = time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := drainer.Drain(ctx, hostname); err == nil {
+		t.Fatal("expected error from the Drain method")
+	}
+	if len(*evicted) != 0 {
+		t.Fatalf("expected no pods to be evicted, but got %v", *evicted)
+	}
+}
+
+func TestFindNode(t *testing.T) {
+	ctx := context.Background()
+	kubeClient, _ := newTestKubeClient(0)
+	drainer := drain.New(kubeClient, nil)
+
+	k8sNode, err := drainer.FindNode(ctx, &node.View{Hostname: "unknown", IP: "10.0.0.10"})
+	if err == nil {
+		t.Fatalf("expected ErrNodeNotFound, but got %s node", k8sNode.Name)
+	}
+
+	byIP := readyNode("renamed", time.Now())
+	byIP.Status.Addresses = []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: "10.0.0.20"}}
+	if _, err := kubeClient.CoreV1().Nodes().Create(ctx, byIP, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	k8sNode, err = drainer.FindNode(ctx, &node.View{Hostname: "unknown", IP: "10.0.0.20"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k8sNode.Name != "renamed" {
+		t.Fatalf("expected renamed node, but got %s", k8sNode.Name)
+	}
+}
+
+func TestReinstallNode(t *testing.T) {
+	ctx := context.Background()
+	kubeClient, evicted := newTestKubeClient(0)
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	testEnv.Mux.HandleFunc(fmt.Sprintf("/v1/clusters/%s/nodegroups/%s/%s/reinstall", clusterID, nodegroupID, nodeID),
+		func(w http.ResponseWriter, r *http.Request) {
+			// The node becomes Ready again after the reinstall. The API server clock is behind
+			// the local one, so the transition time is earlier than the local time.
+			reinstalled := readyNode(hostname, time.Now().Add(-time.Minute))
+			reinstalled.Spec.Unschedulable = true
+			if _, err := kubeClient.CoreV1().Nodes().Update(ctx, reinstalled, metav1.UpdateOptions{}); err != nil {
+				t.Error(err)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+	drainer := drain.New(kubeClient, newTestMKSClient(testEnv))
+	drainer.PollInterval = time.Millisecond
+	if err := drainer.ReinstallNode(ctx, clusterID, nodegroupID, testMKSNode); err != nil {
+		t.Fatal(err)
+	}
+
+	k8sNode, err := kubeClient.CoreV1().Nodes().Get(ctx, hostname, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k8sNode.Spec.Unschedulable {
+		t.Fatal("expected the reinstalled node to be uncordoned")
+	}
+	assertDrained(ctx, t, kubeClient, *evicted)
+}
+
+func TestDeleteNode(t *testing.T) {
+	ctx := context.Background()
+	kubeClient, evicted := newTestKubeClient(0)
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	var (
+		mu    sync.Mutex
+		nodes = []*node.View{testMKSNode}
+	)
+	nodegroupURL := fmt.Sprintf("/v1/clusters/%s/nodegroups/%s", clusterID, nodegroupID)
+	testEnv.Mux.HandleFunc(nodegroupURL, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Add("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"nodegroup": map[string]interface{}{"id": nodegroupID, "status": "ACTIVE", "nodes": nodes},
+		})
+	})
+	testEnv.Mux.HandleFunc(nodegroupURL+"/"+nodeID, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("expected %s method but got %s", http.MethodDelete, r.Method)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		nodes = []*node.View{{ID: "replacement", Hostname: "test-cluster-node-1", NodegroupID: nodegroupID}}
+		if _, err := kubeClient.CoreV1().Nodes().Create(ctx, readyNode("test-cluster-node-1", time.Now()),
+			metav1.CreateOptions{}); err != nil {
+			t.Error(err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	drainer := drain.New(kubeClient, newTestMKSClient(testEnv))
+	drainer.PollInterval = time.Millisecond
+	if err := drainer.DeleteNode(ctx, clusterID, nodegroupID, testMKSNode); err != nil {
+		t.Fatal(err)
+	}
+	assertDrained(ctx, t, kubeClient, *evicted)
+}
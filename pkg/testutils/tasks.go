@@ -0,0 +1,105 @@
+package testutils
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FakeTask represents a cluster task of the FakeTasks API.
+type FakeTask struct {
+	ID          string     `json:"id"`
+	ClusterID   string     `json:"cluster_id"`
+	NodeGroupID string     `json:"nodegroup_id,omitempty"`
+	Type        string     `json:"type"`
+	Status      string     `json:"status"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+}
+
+// FakeTasks emulates the cluster tasks endpoints of the MKS API.
+type FakeTasks struct {
+	mu        sync.Mutex
+	clusterID string
+	tasks     []*FakeTask
+	notFound  bool
+}
+
+// HandleFakeTasks registers the tasks list and get handlers of the cluster.
+func HandleFakeTasks(mux *http.ServeMux, clusterID string) *FakeTasks {
+	fake := &FakeTasks{clusterID: clusterID}
+	tasksURL := "/v1/clusters/" + clusterID + "/tasks"
+
+	mux.HandleFunc(tasksURL, func(w http.ResponseWriter, r *http.Request) {
+		fake.mu.Lock()
+		defer fake.mu.Unlock()
+		if fake.notFound {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+		fake.writeJSON(w, map[string]interface{}{"tasks": fake.tasks})
+	})
+	mux.HandleFunc(tasksURL+"/", func(w http.ResponseWriter, r *http.Request) {
+		fake.mu.Lock()
+		defer fake.mu.Unlock()
+		taskID := strings.TrimPrefix(r.URL.Path, tasksURL+"/")
+		for _, task := range fake.tasks {
+			if task.ID == taskID && !fake.notFound {
+				fake.writeJSON(w, map[string]interface{}{"task": task})
+
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	return fake
+}
+
+// Add adds a task with the provided parameters and the current start time.
+func (fake *FakeTasks) Add(id, taskType, status, nodegroupID string) *FakeTask {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+
+	now := time.Now().UTC()
+	task := &FakeTask{
+		ID:          id,
+		ClusterID:   fake.clusterID,
+		NodeGroupID: nodegroupID,
+		Type:        taskType,
+		Status:      status,
+		StartedAt:   &now,
+		UpdatedAt:   &now,
+	}
+	fake.tasks = append(fake.tasks, task)
+
+	return task
+}
+
+// SetStatus changes the status of the task referenced by its id.
+func (fake *FakeTasks) SetStatus(id, status string) {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+
+	for _, task := range fake.tasks {
+		if task.ID == id {
+			task.Status = status
+		}
+	}
+}
+
+// SetNotFound makes the handlers respond with the not found status.
+func (fake *FakeTasks) SetNotFound(notFound bool) {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+
+	fake.notFound = notFound
+}
+
+func (fake *FakeTasks) writeJSON(w http.ResponseWriter, body interface{}) {
+	w.Header().Add("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,132 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	v1 "github.com/selectel/mks-go/pkg/v1"
+	"github.com/selectel/mks-go/pkg/v1/kubeoptions"
+	"github.com/selectel/mks-go/pkg/v1/kubeversion"
+)
+
+// ErrKubeOptionsNotFound is returned when there are no available feature gates
+// or admission controllers for the Kubernetes version.
+var ErrKubeOptionsNotFound = errors.New("no feature gates or admission controllers for the Kubernetes version")
+
+// CompatibilityReport represents feature gates and admission controllers of a cluster
+// that are not available in the target Kubernetes version.
+type CompatibilityReport struct {
+	// TargetVersion represents the checked Kubernetes version.
+	TargetVersion string
+
+	// DroppedFeatureGates contains enabled feature gates that are not available in the target version.
+	DroppedFeatureGates []string
+
+	// DroppedAdmissionControllers contains enabled admission controllers that are not available
+	// in the target version.
+	DroppedAdmissionControllers []string
+}
+
+// IsCompatible returns true if all enabled feature gates and admission controllers
+// are available in the target version.
+func (report *CompatibilityReport) IsCompatible() bool {
+	return len(report.DroppedFeatureGates) == 0 && len(report.DroppedAdmissionControllers) == 0
+}
+
+// UpdateOpts builds options for the cluster Update request that remove dropped feature gates
+// and admission controllers from the provided Kubernetes options.
+// It can be applied before the upgrade. Other Kubernetes options are left unchanged.
+func (report *CompatibilityReport) UpdateOpts(current *KubernetesOptions) *UpdateOpts {
+	kubernetesOptions := KubernetesOptions{}
+	if current != nil {
+		kubernetesOptions = *current
+	}
+	kubernetesOptions.FeatureGates = withoutNames(kubernetesOptions.FeatureGates, report.DroppedFeatureGates)
+	kubernetesOptions.AdmissionControllers = withoutNames(kubernetesOptions.AdmissionControllers,
+		report.DroppedAdmissionControllers)
+
+	return &UpdateOpts{
+		KubernetesOptions: &kubernetesOptions,
+	}
+}
+
+// CheckKubeOptions checks that feature gates and admission controllers from the provided
+// Kubernetes options exist in the target Kubernetes version. Available feature gates and
+// admission controllers should be retrieved with the kubeoptions ListFeatureGates and
+// ListAdmissionControllers requests.
+func CheckKubeOptions(current *KubernetesOptions, targetVersion string,
+	featureGates, admissionControllers []*kubeoptions.View,
+) (*CompatibilityReport, error) {
+	availableFG := kubeoptions.FindByKubeVersion(featureGates, targetVersion)
+	if availableFG == nil {
+		return nil, fmt.Errorf("%w: %s", ErrKubeOptionsNotFound, targetVersion)
+	}
+	availableAC := kubeoptions.FindByKubeVersion(admissionControllers, targetVersion)
+	if availableAC == nil {
+		return nil, fmt.Errorf("%w: %s", ErrKubeOptionsNotFound, targetVersion)
+	}
+
+	report := &CompatibilityReport{
+		TargetVersion: targetVersion,
+	}
+	if current != nil {
+		report.DroppedFeatureGates = missingNames(current.FeatureGates, availableFG.Names)
+		report.DroppedAdmissionControllers = missingNames(current.AdmissionControllers, availableAC.Names)
+	}
+
+	return report, nil
+}
+
+// CheckUpgradeCompatibility checks that feature gates and admission controllers of the cluster
+// exist in the target Kubernetes version.
+// The next minor version after the cluster version is checked if the target version is empty.
+func CheckUpgradeCompatibility(ctx context.Context, client *v1.ServiceClient, clusterView *GetView,
+	targetVersion string,
+) (*CompatibilityReport, error) {
+	if targetVersion == "" {
+		currentVersion, err := kubeversion.ParseVersion(clusterView.KubeVersion)
+		if err != nil {
+			return nil, err
+		}
+		currentVersion.Minor++
+		targetVersion = currentVersion.MinorString()
+	}
+
+	featureGates, _, err := kubeoptions.ListFeatureGates(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	admissionControllers, _, err := kubeoptions.ListAdmissionControllers(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return CheckKubeOptions(clusterView.KubernetesOptions, targetVersion, featureGates, admissionControllers)
+}
+
+// missingNames returns names that are not present in the available names.
+func missingNames(names, available []string) []string {
+	availableSet := make(map[string]struct{}, len(available))
+	for _, name := range available {
+		availableSet[name] = struct{}{}
+	}
+
+	var missing []string
+	for _, name := range names {
+		if _, ok := availableSet[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+
+	return missing
+}
+
+// withoutNames returns names without the excluded ones.
+func withoutNames(names, excluded []string) []string {
+	if names == nil {
+		return nil
+	}
+
+	return append([]string{}, missingNames(names, excluded)...)
+}
@@ -125,5 +125,100 @@ Example of upgrading Kubernetes minor version by cluster id
 	  log.Fatal(err)
 	}
 	fmt.Printf("%+v\n", mksCluster)
+
+Example of exporting a cluster specification and creating a copy of the cluster from it
+
+	spec, err := cluster.ExportSpec(ctx, mksClient, clusterID)
+	if err != nil {
+	  log.Fatal(err)
+	}
+	specYAML, err := spec.YAML()
+	if err != nil {
+	  log.Fatal(err)
+	}
+	fmt.Print(string(specYAML))
+
+	stagingSpec, err := cluster.ParseSpec(specYAML)
+	if err != nil {
+	  log.Fatal(err)
+	}
+	stagingSpec.Name = "staging-cluster"
+	stagingCluster, _, err := cluster.Create(ctx, mksClient, stagingSpec.CreateOpts())
+	if err != nil {
+	  log.Fatal(err)
+	}
+	fmt.Printf("%+v\n", stagingCluster)
+
+Example of checking that feature gates and admission controllers of a cluster are available
+in the next minor version and removing unavailable ones before the upgrade
+
+	report, err := cluster.CheckUpgradeCompatibility(ctx, mksClient, mksCluster, "")
+	if err != nil {
+	  log.Fatal(err)
+	}
+	if !report.IsCompatible() {
+	  fmt.Println("Dropped feature gates:", report.DroppedFeatureGates)
+	  fmt.Println("Dropped admission controllers:", report.DroppedAdmissionControllers)
+	  updateOpts := report.UpdateOpts(mksCluster.KubernetesOptions)
+	  _, _, err := cluster.Update(ctx, mksClient, mksCluster.ID, updateOpts)
+	  if err != nil {
+	    log.Fatal(err)
+	  }
+	}
+
+Example of upgrading a cluster to the target Kubernetes version step by step
+
+	progress := make(chan cluster.UpgradeEvent)
+	go func() {
+	  for event := range progress {
+	    fmt.Println(event.Type, event.TaskID)
+	  }
+	}()
+	upgradedCluster, err := cluster.UpgradeTo(ctx, mksClient, clusterID, "1.28.5", &cluster.UpgradeOpts{
+	  PollInterval: 30 * time.Second,
+	  Progress:     progress,
+	})
+	close(progress)
+	if err != nil {
+	  log.Fatal(err)
+	}
+	fmt.Printf("%+v\n", upgradedCluster)
+
+Example of rotating cluster certificates in the next maintenance window
+
+	window, err := mksCluster.MaintenanceWindow()
+	if err != nil {
+	  log.Fatal(err)
+	}
+	fmt.Println("Maintenance window opens at", window.Next(time.Now()))
+	err = cluster.RunInWindow(ctx, mksClient, clusterID, func(ctx context.Context) error {
+	  _, err := cluster.RotateCerts(ctx, mksClient, clusterID)
+	  return err
+	})
+	if err != nil {
+	  log.Fatal(err)
+	}
+
+Example of checking that a cluster can be upgraded before sending the request
+
+	if !mksCluster.Status.CanPerform(cluster.OperationUpgradeMinorVersion) {
+	  log.Fatalf("cluster can't be upgraded in the %s status", mksCluster.Status)
+	}
+
+Example of getting active production clusters with Kubernetes 1.28 sorted by creation time
+
+	listOpts := &cluster.ListOpts{
+	  Name:        "prod-*",
+	  Statuses:    []cluster.Status{cluster.StatusActive},
+	  KubeVersion: "1.28",
+	  SortBy:      cluster.ListSortByCreatedAt,
+	}
+	clusters, _, err := cluster.ListWithOpts(ctx, mksClient, listOpts)
+	if err != nil {
+	  log.Fatal(err)
+	}
+	for _, mksCluster := range clusters {
+	  fmt.Printf("%+v\n", mksCluster)
+	}
 */
 package cluster
@@ -0,0 +1,120 @@
+package cluster
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Validate checks that the name pattern and the sort field are valid.
+func (opts *ListOpts) Validate() error {
+	if _, err := path.Match(opts.Name, ""); err != nil {
+		return fmt.Errorf("invalid cluster name pattern %q: %w", opts.Name, err)
+	}
+	switch opts.SortBy {
+	case "", ListSortByCreatedAt, ListSortByUpdatedAt:
+	default:
+		return fmt.Errorf("unsupported cluster sort field: %s", opts.SortBy)
+	}
+
+	return nil
+}
+
+// Match returns true if the cluster matches all filters of the options.
+func (opts *ListOpts) Match(clusterView *ListView) bool {
+	return opts.matchName(clusterView.Name) &&
+		opts.matchStatus(clusterView.Status) &&
+		(opts.Region == "" || clusterView.Region == opts.Region) &&
+		opts.matchKubeVersion(clusterView.KubeVersion) &&
+		(opts.CNIType == "" || clusterView.CNIType == opts.CNIType) &&
+		opts.matchLabel(clusterView.Name)
+}
+
+func (opts *ListOpts) matchName(name string) bool {
+	if opts.Name == "" {
+		return true
+	}
+	matched, err := path.Match(opts.Name, name)
+
+	return err == nil && matched
+}
+
+func (opts *ListOpts) matchStatus(status Status) bool {
+	if len(opts.Statuses) == 0 {
+		return true
+	}
+	for _, s := range opts.Statuses {
+		if s == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (opts *ListOpts) matchKubeVersion(kubeVersion string) bool {
+	if opts.KubeVersion == "" {
+		return true
+	}
+
+	return kubeVersion == opts.KubeVersion || strings.HasPrefix(kubeVersion, opts.KubeVersion+".")
+}
+
+func (opts *ListOpts) matchLabel(name string) bool {
+	if opts.Label == "" {
+		return true
+	}
+	nameLabels := opts.NameLabels
+	if nameLabels == nil {
+		nameLabels = func(name string) []string {
+			return strings.Split(name, "-")
+		}
+	}
+	for _, label := range nameLabels(name) {
+		if label == opts.Label {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Filter returns clusters that match the options sorted by the SortBy field.
+// The provided slice isn't modified. Nil options match all clusters.
+func Filter(clusters []*ListView, opts *ListOpts) []*ListView {
+	if opts == nil {
+		opts = &ListOpts{}
+	}
+	filtered := make([]*ListView, 0, len(clusters))
+	for _, clusterView := range clusters {
+		if opts.Match(clusterView) {
+			filtered = append(filtered, clusterView)
+		}
+	}
+	if opts.SortBy == "" {
+		return filtered
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return opts.less(filtered[i], filtered[j])
+	})
+
+	return filtered
+}
+
+// less compares clusters by the SortBy field placing clusters without the field value last.
+func (opts *ListOpts) less(a, b *ListView) bool {
+	aTime, bTime := a.CreatedAt, b.CreatedAt
+	if opts.SortBy == ListSortByUpdatedAt {
+		aTime, bTime = a.UpdatedAt, b.UpdatedAt
+	}
+	if aTime == nil || bTime == nil {
+		return aTime != nil
+	}
+	if opts.SortDescending {
+		return aTime.After(*bTime)
+	}
+
+	return aTime.Before(*bTime)
+}
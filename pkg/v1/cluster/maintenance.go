@@ -0,0 +1,150 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	v1 "github.com/selectel/mks-go/pkg/v1"
+)
+
+// maintenanceTimeLayout represents the format of maintenance window start and end.
+const maintenanceTimeLayout = "15:04:05"
+
+// day represents the length of a maintenance schedule cycle.
+const day = 24 * time.Hour
+
+// ErrInvalidMaintenanceWindow is returned when maintenance window start or end can't be parsed.
+var ErrInvalidMaintenanceWindow = errors.New("invalid maintenance window")
+
+// MaintenanceWindow represents a daily cluster maintenance window in UTC.
+// The window crosses midnight if its end is before its start.
+type MaintenanceWindow struct {
+	// Start represents the offset from midnight in UTC of when the window opens.
+	Start time.Duration
+
+	// End represents the offset from midnight in UTC of when the window closes.
+	End time.Duration
+}
+
+// ParseMaintenanceWindow parses maintenance window start and end in "hh:mm:ss" format.
+func ParseMaintenanceWindow(start, end string) (*MaintenanceWindow, error) {
+	startOffset, err := parseMaintenanceTime(start)
+	if err != nil {
+		return nil, err
+	}
+	endOffset, err := parseMaintenanceTime(end)
+	if err != nil {
+		return nil, err
+	}
+	if startOffset == endOffset {
+		return nil, fmt.Errorf("%w: start and end are equal: %s", ErrInvalidMaintenanceWindow, start)
+	}
+
+	return &MaintenanceWindow{
+		Start: startOffset,
+		End:   endOffset,
+	}, nil
+}
+
+// parseMaintenanceTime parses "hh:mm:ss" string into the offset from midnight.
+func parseMaintenanceTime(value string) (time.Duration, error) {
+	t, err := time.Parse(maintenanceTimeLayout, value)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q isn't in hh:mm:ss format", ErrInvalidMaintenanceWindow, value)
+	}
+
+	return time.Duration(t.Hour())*time.Hour +
+		time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second, nil
+}
+
+// MaintenanceWindow parses maintenance window start and end of the cluster.
+func (result *BaseView) MaintenanceWindow() (*MaintenanceWindow, error) {
+	return ParseMaintenanceWindow(result.MaintenanceWindowStart, result.MaintenanceWindowEnd)
+}
+
+// String returns the window in "hh:mm:ss-hh:mm:ss" format.
+func (window *MaintenanceWindow) String() string {
+	midnight := time.Time{}
+
+	return midnight.Add(window.Start).Format(maintenanceTimeLayout) + "-" +
+		midnight.Add(window.End).Format(maintenanceTimeLayout)
+}
+
+// CrossesMidnight returns true if the window closes on the day after it opens.
+func (window *MaintenanceWindow) CrossesMidnight() bool {
+	return window.End < window.Start
+}
+
+// Duration returns the length of the window.
+func (window *MaintenanceWindow) Duration() time.Duration {
+	if window.CrossesMidnight() {
+		return day - window.Start + window.End
+	}
+
+	return window.End - window.Start
+}
+
+// Contains returns true if the window is open at the provided time.
+func (window *MaintenanceWindow) Contains(t time.Time) bool {
+	offset := sinceMidnight(t)
+	if window.CrossesMidnight() {
+		return offset >= window.Start || offset < window.End
+	}
+
+	return offset >= window.Start && offset < window.End
+}
+
+// Next returns the time in UTC of when the window opens next after the provided time.
+// It doesn't check if the window is already open, use Contains for that.
+func (window *MaintenanceWindow) Next(now time.Time) time.Time {
+	now = now.UTC()
+	next := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).Add(window.Start)
+	if !next.After(now) {
+		next = next.Add(day)
+	}
+
+	return next
+}
+
+// Until returns the time left before the window opens.
+// Zero is returned if the window is open at the provided time.
+func (window *MaintenanceWindow) Until(now time.Time) time.Duration {
+	if window.Contains(now) {
+		return 0
+	}
+
+	return window.Next(now).Sub(now)
+}
+
+// sinceMidnight returns the offset of the provided time from midnight in UTC.
+func sinceMidnight(t time.Time) time.Duration {
+	t = t.UTC()
+
+	return t.Sub(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC))
+}
+
+// RunInWindow waits until the maintenance window of a cluster referenced by its id is open
+// and calls the provided function. It can be used to defer disruptive operations such as
+// upgrades, certificates rotation or nodes reinstall.
+// The function is called immediately if the window is already open.
+func RunInWindow(ctx context.Context, client *v1.ServiceClient, clusterID string,
+	fn func(ctx context.Context) error,
+) error {
+	mksCluster, _, err := Get(ctx, client, clusterID)
+	if err != nil {
+		return err
+	}
+	window, err := mksCluster.MaintenanceWindow()
+	if err != nil {
+		return err
+	}
+
+	if err := v1.Sleep(ctx, window.Until(time.Now())); err != nil {
+		return err
+	}
+
+	return fn(ctx)
+}
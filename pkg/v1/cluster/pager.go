@@ -0,0 +1,21 @@
+package cluster
+
+import (
+	"strings"
+
+	v1 "github.com/selectel/mks-go/pkg/v1"
+	"github.com/selectel/mks-go/pkg/v1/internal/paging"
+)
+
+// Pager iterates over clusters of the project.
+type Pager = paging.Pager[*ListView]
+
+// NewPager returns a pager over clusters that requests pages of the provided size.
+// All clusters are requested at once if the limit isn't positive.
+func NewPager(client *v1.ServiceClient, limit int) *Pager {
+	url := strings.Join([]string{client.Endpoint, v1.ResourceURLCluster}, "/")
+
+	return paging.NewPager(client, url, "clusters", limit, func(clusterView *ListView) string {
+		return clusterView.ID
+	})
+}
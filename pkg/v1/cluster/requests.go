@@ -58,6 +58,26 @@ func List(ctx context.Context, client *v1.ServiceClient) ([]*ListView, *v1.Respo
 	return result.Clusters, responseResult, nil
 }
 
+// ListWithOpts gets a list of all clusters that match the provided options.
+// The MKS V1 API doesn't support filtering and sorting of clusters, so all clusters
+// are retrieved and the options are applied on the client side. All clusters are returned
+// if the options are nil.
+func ListWithOpts(ctx context.Context, client *v1.ServiceClient, opts *ListOpts) ([]*ListView, *v1.ResponseResult, error) {
+	if opts == nil {
+		opts = &ListOpts{}
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	clusters, responseResult, err := List(ctx, client)
+	if err != nil {
+		return nil, responseResult, err
+	}
+
+	return Filter(clusters, opts), responseResult, nil
+}
+
 // Create requests a creation of a new cluster.
 func Create(ctx context.Context, client *v1.ServiceClient, opts *CreateOpts) (*GetView, *v1.ResponseResult, error) {
 	createClusterOpts := struct {
@@ -82,3 +82,46 @@ type UpdateOpts struct {
 	// feature gates, admission controllers, audit logs and oidc.
 	KubernetesOptions *KubernetesOptions `json:"kubernetes_options,omitempty"`
 }
+
+// ListSortField represents custom type for fields that can be used to sort clusters.
+type ListSortField string
+
+const (
+	ListSortByCreatedAt ListSortField = "created_at"
+	ListSortByUpdatedAt ListSortField = "updated_at"
+)
+
+// ListOpts represents options for the cluster ListWithOpts request.
+// Empty fields are ignored.
+type ListOpts struct {
+	// Name represents an exact cluster name or a glob pattern in the path.Match syntax.
+	Name string
+
+	// Statuses contains allowed cluster statuses.
+	Statuses []Status
+
+	// Region represents the region of clusters.
+	Region string
+
+	// KubeVersion represents the Kubernetes version of clusters in x.y.z format
+	// or the minor version in x.y format.
+	KubeVersion string
+
+	// CNIType represents the CNI type of clusters.
+	CNIType CNIType
+
+	// Label represents a label that is encoded in cluster names.
+	// By default, names are split into labels by hyphens, so "prod" label matches
+	// the "prod-payments-1" cluster.
+	Label string
+
+	// NameLabels extracts labels from cluster names if a different naming convention is used.
+	NameLabels func(name string) []string
+
+	// SortBy represents the field that clusters are sorted by. Clusters without
+	// the field value are placed last. The order of the API is kept if it's empty.
+	SortBy ListSortField
+
+	// SortDescending reverses the sorting order.
+	SortDescending bool
+}
@@ -3,6 +3,8 @@ package cluster
 import (
 	"encoding/json"
 	"time"
+
+	v1 "github.com/selectel/mks-go/pkg/v1"
 )
 
 // Status represents custom type for various cluster statuses.
@@ -64,6 +66,17 @@ func isStatusSupported(s Status) bool {
 	return false
 }
 
+// parseStatus returns the status if it's supported or StatusUnknown otherwise.
+// Unsupported statuses are reported with v1.ReportUnknownValue.
+func parseStatus(raw string) Status {
+	if isStatusSupported(Status(raw)) {
+		return Status(raw)
+	}
+	v1.ReportUnknownValue(v1.UnknownValueClusterStatus, raw)
+
+	return StatusUnknown
+}
+
 // BaseView represents a base struct of unmarshalled nodegroup body from an API response.
 type BaseView struct {
 	// ID is the identifier of the cluster.
@@ -81,6 +94,10 @@ type BaseView struct {
 	// Status represents current status of the cluster.
 	Status Status `json:"-"`
 
+	// RawStatus contains the status as it was returned by the API.
+	// It's kept even if Status is StatusUnknown.
+	RawStatus string `json:"-"`
+
 	// ProjectID contains reference to the project of the cluster.
 	ProjectID string `json:"project_id"`
 
@@ -150,13 +167,17 @@ type GetView struct {
 	// CNICiliumSettings represents settings for Cilium CNI if this CNI was chosen as "cni_type",
 	// otherwise settings will be ignored.
 	CNICiliumSettings *CNICiliumSettings `json:"cni_cilium_settings,omitempty"`
+
+	// Extra contains fields of the API response that are not modelled by the client.
+	// They are kept to be marshalled back.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 func (result *ListView) UnmarshalJSON(b []byte) error {
 	type tmp ListView
 	var s struct {
 		tmp
-		Status Status `json:"status"`
+		Status string `json:"status"`
 	}
 	if err := json.Unmarshal(b, &s); err != nil {
 		return err
@@ -165,11 +186,8 @@ func (result *ListView) UnmarshalJSON(b []byte) error {
 	*result = ListView(s.tmp)
 
 	// Check cluster status.
-	if isStatusSupported(s.Status) {
-		result.Status = s.Status
-	} else {
-		result.Status = StatusUnknown
-	}
+	result.Status = parseStatus(s.Status)
+	result.RawStatus = s.Status
 
 	return nil
 }
@@ -178,7 +196,7 @@ func (result *GetView) UnmarshalJSON(b []byte) error {
 	type tmp GetView
 	var s struct {
 		tmp
-		Status Status `json:"status"`
+		Status string `json:"status"`
 	}
 	if err := json.Unmarshal(b, &s); err != nil {
 		return err
@@ -187,15 +205,35 @@ func (result *GetView) UnmarshalJSON(b []byte) error {
 	*result = GetView(s.tmp)
 
 	// Check cluster status.
-	if isStatusSupported(s.Status) {
-		result.Status = s.Status
-	} else {
-		result.Status = StatusUnknown
+	result.Status = parseStatus(s.Status)
+	result.RawStatus = s.Status
+
+	extra, err := v1.ExtractExtraFields(b, result, "status")
+	if err != nil {
+		return err
 	}
+	result.Extra = extra
 
 	return nil
 }
 
+// MarshalJSON marshals the cluster with its raw status and extra fields.
+func (result GetView) MarshalJSON() ([]byte, error) {
+	type tmp GetView
+	s := struct {
+		tmp
+		Status string `json:"status"`
+	}{
+		tmp:    tmp(result),
+		Status: result.RawStatus,
+	}
+	if s.Status == "" {
+		s.Status = string(result.Status)
+	}
+
+	return v1.MarshalWithExtraFields(s, result.Extra)
+}
+
 // KubernetesOptions represents additional k8s options such as pod security policy,
 // feature gates, admission controllers, audit logs and oidc.
 type KubernetesOptions struct {
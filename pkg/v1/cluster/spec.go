@@ -0,0 +1,202 @@
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+
+	v1 "github.com/selectel/mks-go/pkg/v1"
+	"github.com/selectel/mks-go/pkg/v1/nodegroup"
+)
+
+// Spec represents a portable specification of a cluster that can be stored
+// in a YAML or JSON file and used to create a cluster with the same layout.
+type Spec struct {
+	// Name represents the name of the cluster.
+	Name string `json:"name"`
+
+	// Region represents the region of where the cluster is located.
+	Region string `json:"region"`
+
+	// KubeVersion represents the Kubernetes version of the cluster.
+	KubeVersion string `json:"kube_version"`
+
+	// Zonal specifies that only a single zonal master is used.
+	Zonal *bool `json:"zonal,omitempty"`
+
+	// PrivateKubeAPI specifies if kube API is not available from the Internet.
+	PrivateKubeAPI *bool `json:"private_kube_api,omitempty"`
+
+	// CNIType represents type of CNI which is used in the cluster.
+	CNIType CNIType `json:"cni_type,omitempty"`
+
+	// CNICiliumSettings represents settings for Cilium CNI.
+	CNICiliumSettings *CNICiliumSettings `json:"cni_cilium_settings,omitempty"`
+
+	// KubernetesOptions represents additional k8s options such as pod security policy,
+	// feature gates, admission controllers, audit logs and oidc.
+	KubernetesOptions *KubernetesOptions `json:"kubernetes_options,omitempty"`
+
+	// MaintenanceWindowStart represents UTC time in "hh:mm:ss" format of when the cluster
+	// starts its maintenance tasks.
+	MaintenanceWindowStart string `json:"maintenance_window_start,omitempty"`
+
+	// EnableAutorepair reflects if worker nodes are allowed to be reinstalled automatically.
+	EnableAutorepair *bool `json:"enable_autorepair,omitempty"`
+
+	// EnablePatchVersionAutoUpgrade specifies if Kubernetes patch version of the cluster
+	// is allowed to be upgraded automatically.
+	EnablePatchVersionAutoUpgrade *bool `json:"enable_patch_version_auto_upgrade,omitempty"`
+
+	// Nodegroups contains parameters of every nodegroup of the cluster.
+	Nodegroups []*nodegroup.CreateOpts `json:"nodegroups"`
+}
+
+// NewSpec builds a cluster specification from the cluster and its nodegroups.
+func NewSpec(clusterView *GetView, nodegroupViews []*nodegroup.GetView) *Spec {
+	zonal := clusterView.Zonal
+	privateKubeAPI := clusterView.PrivateKubeAPI
+	enableAutorepair := clusterView.EnableAutorepair
+	enablePatchVersionAutoUpgrade := clusterView.EnablePatchVersionAutoUpgrade
+
+	spec := &Spec{
+		Name:                          clusterView.Name,
+		Region:                        clusterView.Region,
+		KubeVersion:                   clusterView.KubeVersion,
+		Zonal:                         &zonal,
+		PrivateKubeAPI:                &privateKubeAPI,
+		CNIType:                       clusterView.CNIType,
+		MaintenanceWindowStart:        clusterView.MaintenanceWindowStart,
+		EnableAutorepair:              &enableAutorepair,
+		EnablePatchVersionAutoUpgrade: &enablePatchVersionAutoUpgrade,
+		Nodegroups:                    make([]*nodegroup.CreateOpts, 0, len(nodegroupViews)),
+	}
+	if clusterView.CNICiliumSettings != nil {
+		ciliumSettings := *clusterView.CNICiliumSettings
+		spec.CNICiliumSettings = &ciliumSettings
+	}
+	if clusterView.KubernetesOptions != nil {
+		kubernetesOptions := *clusterView.KubernetesOptions
+		kubernetesOptions.FeatureGates = append([]string(nil), kubernetesOptions.FeatureGates...)
+		kubernetesOptions.AdmissionControllers = append([]string(nil), kubernetesOptions.AdmissionControllers...)
+		spec.KubernetesOptions = &kubernetesOptions
+	}
+	for _, nodegroupView := range nodegroupViews {
+		spec.Nodegroups = append(spec.Nodegroups, nodegroupView.CreateOpts())
+	}
+
+	return spec
+}
+
+// ExportSpec builds a specification of an existing cluster referenced by its id.
+func ExportSpec(ctx context.Context, client *v1.ServiceClient, clusterID string) (*Spec, error) {
+	clusterView, _, err := Get(ctx, client, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	nodegroups, _, err := nodegroup.List(ctx, client, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Nodegroups are requested one by one because only the Get response contains user data.
+	nodegroupViews := make([]*nodegroup.GetView, 0, len(nodegroups))
+	for _, ng := range nodegroups {
+		nodegroupView, _, err := nodegroup.Get(ctx, client, clusterID, ng.ID)
+		if err != nil {
+			return nil, err
+		}
+		nodegroupViews = append(nodegroupViews, nodegroupView)
+	}
+
+	return NewSpec(clusterView, nodegroupViews), nil
+}
+
+// ParseSpec parses a cluster specification in the YAML or JSON format.
+func ParseSpec(data []byte) (*Spec, error) {
+	// YAML is a superset of JSON so both formats are decoded into a generic
+	// structure first and then converted into the Spec through JSON to reuse
+	// its field names.
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	jsonData, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var spec Spec
+	if err := json.Unmarshal(jsonData, &spec); err != nil {
+		return nil, err
+	}
+
+	return &spec, nil
+}
+
+// JSON returns the cluster specification in the JSON format.
+func (spec *Spec) JSON() ([]byte, error) {
+	return json.MarshalIndent(spec, "", "  ")
+}
+
+// YAML returns the cluster specification in the YAML format.
+func (spec *Spec) YAML() ([]byte, error) {
+	jsonData, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode JSON into a YAML node tree to keep the order of fields.
+	var node yaml.Node
+	if err := yaml.Unmarshal(jsonData, &node); err != nil {
+		return nil, err
+	}
+	resetYAMLStyle(&node)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// CreateOpts builds options for the cluster Create request from the specification.
+func (spec *Spec) CreateOpts() *CreateOpts {
+	opts := &CreateOpts{
+		Name:                          spec.Name,
+		KubeVersion:                   spec.KubeVersion,
+		Region:                        spec.Region,
+		Nodegroups:                    make([]*nodegroup.CreateOpts, 0, len(spec.Nodegroups)),
+		MaintenanceWindowStart:        spec.MaintenanceWindowStart,
+		EnableAutorepair:              spec.EnableAutorepair,
+		EnablePatchVersionAutoUpgrade: spec.EnablePatchVersionAutoUpgrade,
+		Zonal:                         spec.Zonal,
+		KubernetesOptions:             spec.KubernetesOptions,
+		PrivateKubeAPI:                spec.PrivateKubeAPI,
+		CNIType:                       spec.CNIType,
+		CNICiliumSettings:             spec.CNICiliumSettings,
+	}
+	for _, nodegroupOpts := range spec.Nodegroups {
+		ngOpts := *nodegroupOpts
+		opts.Nodegroups = append(opts.Nodegroups, &ngOpts)
+	}
+
+	return opts
+}
+
+// resetYAMLStyle drops JSON-specific flow and quoting styles from the YAML node tree.
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
@@ -0,0 +1,74 @@
+package cluster
+
+// Operation represents custom type for cluster operations that depend on the cluster status.
+type Operation string
+
+const (
+	OperationUpdate              Operation = "UPDATE"
+	OperationUpgradePatchVersion Operation = "UPGRADE_PATCH_VERSION"
+	OperationUpgradeMinorVersion Operation = "UPGRADE_MINOR_VERSION"
+	OperationRotateCerts         Operation = "ROTATE_CERTS"
+	OperationDelete              Operation = "DELETE"
+)
+
+// allowedOperations contains operations that the API allows in each known status.
+var allowedOperations = map[Status][]Operation{
+	StatusActive: {
+		OperationUpdate,
+		OperationUpgradePatchVersion,
+		OperationUpgradeMinorVersion,
+		OperationRotateCerts,
+		OperationDelete,
+	},
+	StatusPendingCreate:                      {OperationDelete},
+	StatusPendingUpdate:                      {OperationDelete},
+	StatusPendingUpgrade:                     {OperationDelete},
+	StatusPendingRotateCerts:                 {OperationDelete},
+	StatusPendingDelete:                      {},
+	StatusPendingResize:                      {OperationDelete},
+	StatusPendingNodeReinstall:               {OperationDelete},
+	StatusPendingUpgradePatchVersion:         {OperationDelete},
+	StatusPendingUpgradeMinorVersion:         {OperationDelete},
+	StatusPendingUpdateNodegroup:             {OperationDelete},
+	StatusPendingUpgradeMastersConfiguration: {OperationDelete},
+	StatusPendingUpgradeClusterConfiguration: {OperationDelete},
+	StatusMaintenance:                        {OperationDelete},
+	StatusError:                              {OperationDelete},
+}
+
+// IsStable returns true if the cluster is ready to accept any operation.
+func (s Status) IsStable() bool {
+	return s == StatusActive
+}
+
+// IsError returns true if the cluster has failed.
+func (s Status) IsError() bool {
+	return s == StatusError
+}
+
+// IsPending returns true if the API is changing the cluster.
+// Maintenance is also treated as a pending status.
+func (s Status) IsPending() bool {
+	return isStatusSupported(s) && !s.IsStable() && !s.IsError()
+}
+
+// IsTerminal returns true if the API won't change the status without a new request.
+func (s Status) IsTerminal() bool {
+	return s.IsStable() || s.IsError()
+}
+
+// CanPerform returns true if the API allows the operation for a cluster with the status.
+// It returns true for unknown statuses so the API could make the decision.
+func (s Status) CanPerform(operation Operation) bool {
+	operations, ok := allowedOperations[s]
+	if !ok {
+		return true
+	}
+	for _, allowed := range operations {
+		if allowed == operation {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,133 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/selectel/mks-go/pkg/testutils"
+	v1 "github.com/selectel/mks-go/pkg/v1"
+	"github.com/selectel/mks-go/pkg/v1/cluster"
+	"github.com/selectel/mks-go/pkg/v1/kubeoptions"
+)
+
+func TestCheckUpgradeCompatibility(t *testing.T) {
+	featureGatesEndpointCalled := false
+	admissionControllersEndpointCalled := false
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	testutils.HandleReqWithoutBody(t, &testutils.HandleReqOpts{
+		Mux:         testEnv.Mux,
+		URL:         "/v1/feature-gates",
+		RawResponse: testListFeatureGatesResponseRaw,
+		Method:      http.MethodGet,
+		Status:      http.StatusOK,
+		CallFlag:    &featureGatesEndpointCalled,
+	})
+	testutils.HandleReqWithoutBody(t, &testutils.HandleReqOpts{
+		Mux:         testEnv.Mux,
+		URL:         "/v1/admission-controllers",
+		RawResponse: testListAdmissionControllersResponseRaw,
+		Method:      http.MethodGet,
+		Status:      http.StatusOK,
+		CallFlag:    &admissionControllersEndpointCalled,
+	})
+
+	ctx := context.Background()
+	testClient := &v1.ServiceClient{
+		HTTPClient: &http.Client{},
+		TokenID:    testutils.TokenID,
+		Endpoint:   testEnv.Server.URL + "/v1",
+		UserAgent:  testutils.UserAgent,
+	}
+
+	actual, err := cluster.CheckUpgradeCompatibility(ctx, testClient, expectedGetClusterResponse, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !featureGatesEndpointCalled || !admissionControllersEndpointCalled {
+		t.Fatal("endpoint wasn't called")
+	}
+	if !reflect.DeepEqual(expectedCompatibilityReport, actual) {
+		t.Fatalf("expected %#v, but got %#v", expectedCompatibilityReport, actual)
+	}
+	if actual.IsCompatible() {
+		t.Fatal("expected the report to be incompatible")
+	}
+
+	updateOpts := actual.UpdateOpts(expectedGetClusterResponse.KubernetesOptions)
+	if !reflect.DeepEqual(expectedCompatibilityUpdateOpts, updateOpts) {
+		t.Fatalf("expected %#v, but got %#v", expectedCompatibilityUpdateOpts, updateOpts)
+	}
+}
+
+func TestCheckKubeOptionsCompatible(t *testing.T) {
+	featureGates := []*kubeoptions.View{
+		{KubeVersion: "1.16", Names: []string{"TTLAfterFinished", "CSIMigrationOpenStack"}},
+	}
+	admissionControllers := []*kubeoptions.View{
+		{KubeVersion: "1.16", Names: []string{"NamespaceLifecycle", "LimitRanger"}},
+	}
+
+	actual, err := cluster.CheckKubeOptions(expectedGetClusterResponse.KubernetesOptions, "1.16.3",
+		featureGates, admissionControllers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !actual.IsCompatible() {
+		t.Fatalf("expected the report to be compatible, but got %#v", actual)
+	}
+}
+
+func TestCheckKubeOptionsUnknownVersion(t *testing.T) {
+	featureGates := []*kubeoptions.View{
+		{KubeVersion: "1.16", Names: []string{"TTLAfterFinished"}},
+	}
+
+	actual, err := cluster.CheckKubeOptions(expectedGetClusterResponse.KubernetesOptions, "1.17",
+		featureGates, featureGates)
+	if !errors.Is(err, cluster.ErrKubeOptionsNotFound) {
+		t.Fatalf("expected ErrKubeOptionsNotFound, but got %v", err)
+	}
+	if actual != nil {
+		t.Fatal("expected no report from the CheckKubeOptions method")
+	}
+}
+
+func TestCheckUpgradeCompatibilityHTTPError(t *testing.T) {
+	endpointCalled := false
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	testutils.HandleReqWithoutBody(t, &testutils.HandleReqOpts{
+		Mux:         testEnv.Mux,
+		URL:         "/v1/feature-gates",
+		RawResponse: testErrGenericResponseRaw,
+		Method:      http.MethodGet,
+		Status:      http.StatusBadGateway,
+		CallFlag:    &endpointCalled,
+	})
+
+	ctx := context.Background()
+	testClient := &v1.ServiceClient{
+		HTTPClient: &http.Client{},
+		TokenID:    testutils.TokenID,
+		Endpoint:   testEnv.Server.URL + "/v1",
+		UserAgent:  testutils.UserAgent,
+	}
+
+	actual, err := cluster.CheckUpgradeCompatibility(ctx, testClient, expectedGetClusterResponse, "1.16")
+
+	if !endpointCalled {
+		t.Fatal("endpoint wasn't called")
+	}
+	if actual != nil {
+		t.Fatal("expected no report from the CheckUpgradeCompatibility method")
+	}
+	if err == nil {
+		t.Fatal("expected error from the CheckUpgradeCompatibility method")
+	}
+}
@@ -0,0 +1,113 @@
+package testing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/selectel/mks-go/pkg/testutils"
+	v1 "github.com/selectel/mks-go/pkg/v1"
+	"github.com/selectel/mks-go/pkg/v1/cluster"
+)
+
+func TestGetClusterExtraFields(t *testing.T) {
+	endpointCalled := false
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	testutils.HandleReqWithoutBody(t, &testutils.HandleReqOpts{
+		Mux:         testEnv.Mux,
+		URL:         "/v1/clusters/" + upgradeClusterID,
+		RawResponse: testGetClusterExtraFieldsResponseRaw,
+		Method:      http.MethodGet,
+		Status:      http.StatusOK,
+		CallFlag:    &endpointCalled,
+	})
+
+	ctx := context.Background()
+	testClient := newUpgradeTestClient(testEnv)
+
+	actual, _, err := cluster.Get(ctx, testClient, upgradeClusterID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !endpointCalled {
+		t.Fatal("endpoint wasn't called")
+	}
+	expectedExtra := map[string]json.RawMessage{
+		"gpu_operator":  json.RawMessage(`{"enabled": true}`),
+		"masters_count": json.RawMessage(`3`),
+	}
+	if !reflect.DeepEqual(expectedExtra, actual.Extra) {
+		t.Fatalf("expected %s extra fields, but got %s", expectedExtra, actual.Extra)
+	}
+
+	marshalled, err := json.Marshal(actual)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var expected, roundTrip map[string]interface{}
+	if err := json.Unmarshal([]byte(testGetClusterExtraFieldsRaw), &expected); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(marshalled, &roundTrip); err != nil {
+		t.Fatal(err)
+	}
+	for name, value := range expected {
+		if !reflect.DeepEqual(value, roundTrip[name]) {
+			t.Fatalf("expected %v for the %s field, but got %v", value, name, roundTrip[name])
+		}
+	}
+
+	marshalledValues, err := json.Marshal([]cluster.GetView{*actual})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(marshalledValues) != "["+string(marshalled)+"]" {
+		t.Fatalf("expected values to be marshalled as %s, but got %s", marshalled, marshalledValues)
+	}
+}
+
+func TestGetClusterStrictDecoding(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	testutils.HandleReqWithoutBody(t, &testutils.HandleReqOpts{
+		Mux:         testEnv.Mux,
+		URL:         "/v1/clusters/" + upgradeClusterID,
+		RawResponse: testGetClusterExtraFieldsResponseRaw,
+		Method:      http.MethodGet,
+		Status:      http.StatusOK,
+		CallFlag:    new(bool),
+	})
+	testutils.HandleReqWithoutBody(t, &testutils.HandleReqOpts{
+		Mux:         testEnv.Mux,
+		URL:         "/v1/clusters/dbe7559b-55d8-4f65-9230-6a22b985ff73",
+		RawResponse: testGetClusterResponseRaw,
+		Method:      http.MethodGet,
+		Status:      http.StatusOK,
+		CallFlag:    new(bool),
+	})
+
+	v1.SetStrictDecoding(true)
+	defer v1.SetStrictDecoding(false)
+
+	ctx := context.Background()
+	testClient := newUpgradeTestClient(testEnv)
+
+	_, _, err := cluster.Get(ctx, testClient, upgradeClusterID)
+	if !errors.Is(err, v1.ErrUnknownFields) {
+		t.Fatalf("expected ErrUnknownFields, but got %v", err)
+	}
+
+	actual, _, err := cluster.Get(ctx, testClient, "dbe7559b-55d8-4f65-9230-6a22b985ff73")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expectedGetClusterResponse, actual) {
+		t.Fatalf("expected %#v, but got %#v", expectedGetClusterResponse, actual)
+	}
+}
@@ -74,6 +74,7 @@ var expectedGetClusterResponse = &cluster.GetView{
 		UpdatedAt:                     &clusterResponseTimestamp,
 		Name:                          "test-cluster",
 		Status:                        cluster.StatusActive,
+		RawStatus:                     "ACTIVE",
 		ProjectID:                     "65044a03bede4fd0a77e5a4c882e3059",
 		NetworkID:                     "74a591be-6be7-4abc-d30f-1614c0f9721c",
 		SubnetID:                      "c872541d-2d83-419f-841d-8288201b8fb9",
@@ -179,6 +180,7 @@ var expectedGetZonalClusterResponse = &cluster.GetView{
 		UpdatedAt:                     &clusterResponseTimestamp,
 		Name:                          "test-zonal-cluster",
 		Status:                        cluster.StatusPendingUpgradeMinorVersion,
+		RawStatus:                     "PENDING_UPGRADE_MINOR_VERSION",
 		ProjectID:                     "65044a03bede4fd0a77e5a4c882e3059",
 		NetworkID:                     "74a591be-6be7-4abc-d30f-1614c0f9721c",
 		SubnetID:                      "c872541d-2d83-419f-841d-8288201b8fb9",
@@ -878,6 +880,7 @@ var expectedListClustersResponse = []*cluster.ListView{
 			UpdatedAt:                     &clusterResponseTimestamp,
 			Name:                          "test-cluster",
 			Status:                        cluster.StatusActive,
+			RawStatus:                     "ACTIVE",
 			ProjectID:                     "65044a03bede4fd0a77e5a4c882e3059",
 			NetworkID:                     "74a591be-6be7-4abc-d30f-1614c0f9721c",
 			SubnetID:                      "c872541d-2d83-419f-841d-8288201b8fb9",
@@ -920,6 +923,7 @@ var expectedListClustersResponse = []*cluster.ListView{
 			UpdatedAt:                     &clusterResponseTimestamp,
 			Name:                          "test-cluster-2",
 			Status:                        cluster.StatusPendingCreate,
+			RawStatus:                     "PENDING_CREATE",
 			ProjectID:                     "25044a03bede4fd0a77e5a4c882e3059",
 			NetworkID:                     "24a591be-6be7-4abc-d30f-1614c0f9721c",
 			SubnetID:                      "2872541d-2d83-419f-841d-8288201b8fb9",
@@ -962,6 +966,7 @@ var expectedListClustersResponse = []*cluster.ListView{
 			UpdatedAt:                     &clusterResponseTimestamp,
 			Name:                          "test-cluster-3",
 			Status:                        cluster.StatusPendingUpdate,
+			RawStatus:                     "PENDING_UPDATE",
 			ProjectID:                     "35044a03bede4fd0a77e5a4c882e3059",
 			NetworkID:                     "34a591be-6be7-4abc-d30f-1614c0f9721c",
 			SubnetID:                      "3872541d-2d83-419f-841d-8288201b8fb9",
@@ -1004,6 +1009,7 @@ var expectedListClustersResponse = []*cluster.ListView{
 			UpdatedAt:                     &clusterResponseTimestamp,
 			Name:                          "test-cluster-4",
 			Status:                        cluster.StatusPendingUpgrade,
+			RawStatus:                     "PENDING_UPGRADE",
 			ProjectID:                     "45044a03bede4fd0a77e5a4c882e3059",
 			NetworkID:                     "44a591be-6be7-4abc-d30f-1614c0f9721c",
 			SubnetID:                      "4872541d-2d83-419f-841d-8288201b8fb9",
@@ -1046,6 +1052,7 @@ var expectedListClustersResponse = []*cluster.ListView{
 			UpdatedAt:                     &clusterResponseTimestamp,
 			Name:                          "test-cluster-5",
 			Status:                        cluster.StatusPendingRotateCerts,
+			RawStatus:                     "PENDING_ROTATE_CERTS",
 			ProjectID:                     "55044a03bede4fd0a77e5a4c882e3059",
 			NetworkID:                     "54a591be-6be7-4abc-d30f-1614c0f9721c",
 			SubnetID:                      "5872541d-2d83-419f-841d-8288201b8fb9",
@@ -1088,6 +1095,7 @@ var expectedListClustersResponse = []*cluster.ListView{
 			UpdatedAt:                     &clusterResponseTimestamp,
 			Name:                          "test-cluster-6",
 			Status:                        cluster.StatusPendingDelete,
+			RawStatus:                     "PENDING_DELETE",
 			ProjectID:                     "65044a03bede4fd0a77e5a4c882e3059",
 			NetworkID:                     "64a591be-6be7-4abc-d30f-1614c0f9721c",
 			SubnetID:                      "6872541d-2d83-419f-841d-8288201b8fb9",
@@ -1130,6 +1138,7 @@ var expectedListClustersResponse = []*cluster.ListView{
 			UpdatedAt:                     &clusterResponseTimestamp,
 			Name:                          "test-cluster-7",
 			Status:                        cluster.StatusPendingResize,
+			RawStatus:                     "PENDING_RESIZE",
 			ProjectID:                     "76044a03bede4fd0a77e5a4c882e3059",
 			NetworkID:                     "74a591be-6be7-4abc-d30f-1614c0f9721c",
 			SubnetID:                      "7872541d-2d83-419f-841d-8288201b8fb9",
@@ -1172,6 +1181,7 @@ var expectedListClustersResponse = []*cluster.ListView{
 			UpdatedAt:                     &clusterResponseTimestamp,
 			Name:                          "test-cluster-8",
 			Status:                        cluster.StatusPendingNodeReinstall,
+			RawStatus:                     "PENDING_NODE_REINSTALL",
 			ProjectID:                     "85044a03bede4fd0a77e5a4c882e3059",
 			NetworkID:                     "87a591be-6be7-4abc-d30f-1614c0f9721c",
 			SubnetID:                      "8872541d-2d83-419f-841d-8288201b8fb9",
@@ -1214,6 +1224,7 @@ var expectedListClustersResponse = []*cluster.ListView{
 			UpdatedAt:                     &clusterResponseTimestamp,
 			Name:                          "test-cluster-9",
 			Status:                        cluster.StatusPendingUpgradePatchVersion,
+			RawStatus:                     "PENDING_UPGRADE_PATCH_VERSION",
 			ProjectID:                     "95044a03bede4fd0a77e5a4c882e3059",
 			NetworkID:                     "94a591be-6be7-4abc-d30f-1614c0f9721c",
 			SubnetID:                      "9872541d-2d83-419f-841d-8288201b8fb9",
@@ -1256,6 +1267,7 @@ var expectedListClustersResponse = []*cluster.ListView{
 			UpdatedAt:                     &clusterResponseTimestamp,
 			Name:                          "test-cluster-9",
 			Status:                        cluster.StatusPendingUpgradeMinorVersion,
+			RawStatus:                     "PENDING_UPGRADE_MINOR_VERSION",
 			ProjectID:                     "95044a03bede4fd0a77e5a4c882e3059",
 			NetworkID:                     "94a591be-6be7-4abc-d30f-1614c0f9721c",
 			SubnetID:                      "9872541d-2d83-419f-841d-8288201b8fb9",
@@ -1298,6 +1310,7 @@ var expectedListClustersResponse = []*cluster.ListView{
 			UpdatedAt:                     &clusterResponseTimestamp,
 			Name:                          "test-cluster",
 			Status:                        cluster.StatusPendingUpdateNodegroup,
+			RawStatus:                     "PENDING_UPDATE_NODEGROUP",
 			ProjectID:                     "95044a03bede4fd0a77e5a4c882e3059",
 			NetworkID:                     "94a591be-6be7-4abc-d30f-1614c0f9721c",
 			SubnetID:                      "9872541d-2d83-419f-841d-8288201b8fb9",
@@ -1340,6 +1353,7 @@ var expectedListClustersResponse = []*cluster.ListView{
 			UpdatedAt:                     &clusterResponseTimestamp,
 			Name:                          "test-cluster",
 			Status:                        cluster.StatusPendingUpgradeMastersConfiguration,
+			RawStatus:                     "PENDING_UPGRADE_MASTERS_CONFIGURATION",
 			ProjectID:                     "95044a03bede4fd0a77e5a4c882e3059",
 			NetworkID:                     "94a591be-6be7-4abc-d30f-1614c0f9721c",
 			SubnetID:                      "9872541d-2d83-419f-841d-8288201b8fb9",
@@ -1382,6 +1396,7 @@ var expectedListClustersResponse = []*cluster.ListView{
 			UpdatedAt:                     &clusterResponseTimestamp,
 			Name:                          "test-cluster",
 			Status:                        cluster.StatusPendingUpgradeClusterConfiguration,
+			RawStatus:                     "PENDING_UPGRADE_CLUSTER_CONFIGURATION",
 			ProjectID:                     "95044a03bede4fd0a77e5a4c882e3059",
 			NetworkID:                     "94a591be-6be7-4abc-d30f-1614c0f9721c",
 			SubnetID:                      "9872541d-2d83-419f-841d-8288201b8fb9",
@@ -1424,6 +1439,7 @@ var expectedListClustersResponse = []*cluster.ListView{
 			UpdatedAt:                     &clusterResponseTimestamp,
 			Name:                          "test-cluster-10",
 			Status:                        cluster.StatusMaintenance,
+			RawStatus:                     "MAINTENANCE",
 			ProjectID:                     "10044a03bede4fd0a77e5a4c882e3059",
 			NetworkID:                     "10a591be-6be7-4abc-d30f-1614c0f9721c",
 			SubnetID:                      "1072541d-2d83-419f-841d-8288201b8fb9",
@@ -1466,6 +1482,7 @@ var expectedListClustersResponse = []*cluster.ListView{
 			UpdatedAt:                     &clusterResponseTimestamp,
 			Name:                          "test-cluster-11",
 			Status:                        cluster.StatusError,
+			RawStatus:                     "ERROR",
 			ProjectID:                     "11044a03bede4fd0a77e5a4c882e3059",
 			NetworkID:                     "11a591be-6be7-4abc-d30f-1614c0f9721c",
 			SubnetID:                      "1172541d-2d83-419f-841d-8288201b8fb9",
@@ -1508,6 +1525,7 @@ var expectedListClustersResponse = []*cluster.ListView{
 			UpdatedAt:                     &clusterResponseTimestamp,
 			Name:                          "test-cluster-12",
 			Status:                        cluster.StatusUnknown,
+			RawStatus:                     "UNKNOWN",
 			ProjectID:                     "12044a03bede4fd0a77e5a4c882e3059",
 			NetworkID:                     "12a591be-6be7-4abc-d30f-1614c0f9721c",
 			SubnetID:                      "1272541d-2d83-419f-841d-8288201b8fb9",
@@ -1718,6 +1736,7 @@ var expectedCreateClusterResponse = &cluster.GetView{
 		UpdatedAt:                     nil,
 		Name:                          "test-cluster-0",
 		Status:                        "PENDING_CREATE",
+		RawStatus:                     "PENDING_CREATE",
 		ProjectID:                     "69744a03bebe4fd0a77e5a4c882e3059",
 		NetworkID:                     "",
 		SubnetID:                      "",
@@ -1908,6 +1927,7 @@ var expectedCreateClusterDisableBoolsResponse = &cluster.GetView{
 		UpdatedAt:                     nil,
 		Name:                          "test-cluster-0",
 		Status:                        "PENDING_CREATE",
+		RawStatus:                     "PENDING_CREATE",
 		ProjectID:                     "69744a03bebe4fd0a77e5a4c882e3059",
 		NetworkID:                     "",
 		SubnetID:                      "",
@@ -2015,6 +2035,7 @@ var expectedCreateZonalClusterResponse = &cluster.GetView{
 		UpdatedAt:                     nil,
 		Name:                          "test-zonal-cluster-0",
 		Status:                        "PENDING_CREATE",
+		RawStatus:                     "PENDING_CREATE",
 		ProjectID:                     "69744a03bebe4fd0a77e5a4c882e3059",
 		NetworkID:                     "",
 		SubnetID:                      "",
@@ -2127,6 +2148,7 @@ var expectedCreatePrivateKubeAPIClusterResponse = &cluster.GetView{
 		UpdatedAt:                     nil,
 		Name:                          "test-private-cluster-0",
 		Status:                        "PENDING_CREATE",
+		RawStatus:                     "PENDING_CREATE",
 		ProjectID:                     "69744a03bebe4fd0a77e5a4c882e3059",
 		NetworkID:                     "",
 		SubnetID:                      "",
@@ -2367,6 +2389,7 @@ var expectedUpdateClusterWithEnabledPSPResponse = &cluster.GetView{
 		UpdatedAt:                     nil,
 		Name:                          "test-zonal-cluster-0",
 		Status:                        cluster.StatusPendingUpgradeMastersConfiguration,
+		RawStatus:                     "PENDING_UPGRADE_MASTERS_CONFIGURATION",
 		ProjectID:                     "69744a03bebe4fd0a77e5a4c882e3059",
 		NetworkID:                     "",
 		SubnetID:                      "",
@@ -2388,3 +2411,326 @@ var expectedUpdateClusterWithEnabledPSPResponse = &cluster.GetView{
 		},
 	},
 }
+
+// testListClusterNodegroupsResponseRaw represents a raw response from the nodegroup List request.
+const testListClusterNodegroupsResponseRaw = `
+{
+    "nodegroups": [
+        {
+            "id": "a376745a-fbcb-413d-b418-169d059d79ce",
+            "cluster_id": "dbe7559b-55d8-4f65-9230-6a22b985ff73",
+            "status": "ACTIVE"
+        }
+    ]
+}
+`
+
+// testGetClusterNodegroupResponseRaw represents a raw response from the nodegroup Get request.
+const testGetClusterNodegroupResponseRaw = `
+{
+    "nodegroup": {
+        "availability_zone": "ru-1a",
+        "cluster_id": "dbe7559b-55d8-4f65-9230-6a22b985ff73",
+        "created_at": "2020-02-13T09:18:32.05753Z",
+        "flavor_id": "99b62670-9d78-43fd-8f55-d184a4800f8d",
+        "id": "a376745a-fbcb-413d-b418-169d059d79ce",
+        "local_volume": false,
+        "status": "ACTIVE",
+        "nodes": [
+            {
+                "hostname": "test-cluster-node-eegp9",
+                "id": "39e5dd4d-5e23-4a00-8173-974bf844f21b",
+                "nodegroup_id": "a376745a-fbcb-413d-b418-169d059d79ce"
+            },
+            {
+                "hostname": "test-cluster-node-ab9k2",
+                "id": "49e5dd4d-5e23-4a00-8173-974bf844f21b",
+                "nodegroup_id": "a376745a-fbcb-413d-b418-169d059d79ce"
+            }
+        ],
+        "updated_at": "2020-02-13T09:18:32.05753Z",
+        "volume_gb": 10,
+        "volume_type": "basic.ru-1a",
+        "labels": {
+           "test-label-key": "test-label-value"
+        },
+        "taints": [
+            {
+                "key": "test-key-0",
+                "value": "test-value-0",
+                "effect": "NoSchedule"
+            }
+        ],
+        "enable_autoscale": true,
+        "autoscale_min_nodes": 1,
+        "autoscale_max_nodes": 3,
+        "nodegroup_type": "STANDARD",
+        "user_data": "IyEvYmluL2Jhc2ggLXYKYXB0IC15IHVwZGF0ZQphcHQgLXkgaW5zdGFsbCBtdHI=",
+        "install_nvidia_device_plugin": false,
+        "preemptible": true
+    }
+}
+`
+
+// expectedClusterSpec represents a specification built from testGetClusterResponseRaw
+// and testGetClusterNodegroupResponseRaw.
+var expectedClusterSpec = &cluster.Spec{
+	Name:           "test-cluster",
+	Region:         "ru-1",
+	KubeVersion:    "1.15.7",
+	Zonal:          testutils.BoolToPtr(false),
+	PrivateKubeAPI: testutils.BoolToPtr(false),
+	CNIType:        cluster.CNITypeCilium,
+	CNICiliumSettings: &cluster.CNICiliumSettings{
+		EnvoyDaemonset: testutils.BoolToPtr(true),
+		HubbleRelay:    testutils.BoolToPtr(true),
+	},
+	KubernetesOptions: &cluster.KubernetesOptions{
+		EnablePodSecurityPolicy: true,
+		FeatureGates: []string{
+			"TTLAfterFinished",
+			"CSIMigrationOpenStack",
+		},
+		AdmissionControllers: []string{
+			"NamespaceLifecycle",
+			"LimitRanger",
+		},
+		AuditLogs: cluster.AuditLogs{
+			Enabled:    true,
+			SecretName: "mks-audit-logs",
+		},
+		OIDC: cluster.OIDC{
+			Enabled:       true,
+			ProviderName:  "keycloak",
+			IssuerURL:     "https://example.com/",
+			ClientID:      "kubernetes",
+			UsernameClaim: "email",
+			GroupsClaim:   "groups",
+			CACerts:       "LS0tLS1CRUdJTiBDRVJUSUZJQ0FURS0tLS0tS0tLQo=",
+		},
+	},
+	MaintenanceWindowStart:        "01:00:00",
+	EnableAutorepair:              testutils.BoolToPtr(true),
+	EnablePatchVersionAutoUpgrade: testutils.BoolToPtr(true),
+	Nodegroups: []*nodegroup.CreateOpts{
+		{
+			Count:            2,
+			FlavorID:         "99b62670-9d78-43fd-8f55-d184a4800f8d",
+			VolumeGB:         10,
+			VolumeType:       "basic.ru-1a",
+			AvailabilityZone: "ru-1a",
+			Labels: map[string]string{
+				"test-label-key": "test-label-value",
+			},
+			Taints: []nodegroup.Taint{
+				{
+					Key:    "test-key-0",
+					Value:  "test-value-0",
+					Effect: nodegroup.NoScheduleEffect,
+				},
+			},
+			EnableAutoscale:   testutils.BoolToPtr(true),
+			AutoscaleMinNodes: testutils.IntToPtr(1),
+			AutoscaleMaxNodes: testutils.IntToPtr(3),
+			UserData:          "IyEvYmluL2Jhc2ggLXYKYXB0IC15IHVwZGF0ZQphcHQgLXkgaW5zdGFsbCBtdHI=",
+			Preemptible:       testutils.BoolToPtr(true),
+		},
+	},
+}
+
+// testClusterSpecYAML represents a cluster specification in the YAML format.
+const testClusterSpecYAML = `
+name: staging-cluster
+region: ru-3
+kube_version: 1.28.5
+cni_type: CALICO
+maintenance_window_start: "07:00:00"
+enable_autorepair: false
+nodegroups:
+  - count: 3
+    cpus: 2
+    ram_mb: 4096
+    volume_gb: 20
+    volume_type: fast.ru-3a
+    availability_zone: ru-3a
+    labels:
+      role: worker
+    taints: []
+`
+
+// expectedClusterSpecCreateOpts represents options for the Create request built
+// from testClusterSpecYAML.
+var expectedClusterSpecCreateOpts = &cluster.CreateOpts{
+	Name:        "staging-cluster",
+	KubeVersion: "1.28.5",
+	Region:      "ru-3",
+	Nodegroups: []*nodegroup.CreateOpts{
+		{
+			Count:            3,
+			CPUs:             2,
+			RAMMB:            4096,
+			VolumeGB:         20,
+			VolumeType:       "fast.ru-3a",
+			AvailabilityZone: "ru-3a",
+			Labels: map[string]string{
+				"role": "worker",
+			},
+			Taints: []nodegroup.Taint{},
+		},
+	},
+	MaintenanceWindowStart: "07:00:00",
+	EnableAutorepair:       testutils.BoolToPtr(false),
+	CNIType:                cluster.CNITypeCalico,
+}
+
+// testListFeatureGatesResponseRaw represents a raw response from the kubeoptions ListFeatureGates request.
+const testListFeatureGatesResponseRaw = `
+{
+    "feature_gates": [
+        {
+            "KubeVersionMinor": "1.15",
+            "Names": ["TTLAfterFinished", "CSIMigrationOpenStack"]
+        },
+        {
+            "KubeVersionMinor": "1.16",
+            "Names": ["TTLAfterFinished", "EphemeralContainers"]
+        }
+    ]
+}
+`
+
+// testListAdmissionControllersResponseRaw represents a raw response from the kubeoptions
+// ListAdmissionControllers request.
+const testListAdmissionControllersResponseRaw = `
+{
+    "admission_controllers": [
+        {
+            "KubeVersionMinor": "1.15",
+            "Names": ["NamespaceLifecycle", "LimitRanger"]
+        },
+        {
+            "KubeVersionMinor": "1.16",
+            "Names": ["NamespaceLifecycle", "RuntimeClass"]
+        }
+    ]
+}
+`
+
+// expectedCompatibilityReport represents a compatibility report of expectedGetClusterResponse
+// with the 1.16 Kubernetes version.
+var expectedCompatibilityReport = &cluster.CompatibilityReport{
+	TargetVersion:               "1.16",
+	DroppedFeatureGates:         []string{"CSIMigrationOpenStack"},
+	DroppedAdmissionControllers: []string{"LimitRanger"},
+}
+
+// expectedCompatibilityUpdateOpts represents options for the Update request built
+// from expectedCompatibilityReport.
+var expectedCompatibilityUpdateOpts = &cluster.UpdateOpts{
+	KubernetesOptions: &cluster.KubernetesOptions{
+		EnablePodSecurityPolicy: true,
+		FeatureGates:            []string{"TTLAfterFinished"},
+		AdmissionControllers:    []string{"NamespaceLifecycle"},
+		AuditLogs: cluster.AuditLogs{
+			Enabled:    true,
+			SecretName: "mks-audit-logs",
+		},
+		OIDC: cluster.OIDC{
+			Enabled:       true,
+			ProviderName:  "keycloak",
+			IssuerURL:     "https://example.com/",
+			ClientID:      "kubernetes",
+			UsernameClaim: "email",
+			GroupsClaim:   "groups",
+			CACerts:       "LS0tLS1CRUdJTiBDRVJUSUZJQ0FURS0tLS0tS0tLQo=",
+		},
+	},
+}
+
+// testUpgradeKubeVersionsResponseRaw represents a raw response from the kubeversion List request
+// that is used in upgrade tests.
+const testUpgradeKubeVersionsResponseRaw = `
+{
+    "kube_versions": [
+        {"version": "1.26.3", "is_default": false},
+        {"version": "1.26.9", "is_default": false},
+        {"version": "1.27.8", "is_default": true},
+        {"version": "1.28.5", "is_default": false}
+    ]
+}
+`
+
+// testUpgradePatchVersions represents Kubernetes versions after the patch version upgrade.
+var testUpgradePatchVersions = map[string]string{
+	"1.26.3": "1.26.9",
+}
+
+// testUpgradeMinorVersions represents Kubernetes versions after the minor version upgrade.
+var testUpgradeMinorVersions = map[string]string{
+	"1.26.9": "1.27.8",
+	"1.27.8": "1.28.5",
+}
+
+// testGetClusterExtraFieldsRaw represents a raw cluster with fields that are not modelled by the client.
+const testGetClusterExtraFieldsRaw = `
+{
+    "id": "ab1f1e5c-6a0e-4b2b-bb1c-1d5b7c0f1c2e",
+    "name": "test-cluster",
+    "status": "ACTIVE",
+    "kube_version": "1.26.3",
+    "gpu_operator": {"enabled": true},
+    "masters_count": 3
+}
+`
+
+// testGetClusterExtraFieldsResponseRaw represents a raw response from the Get request
+// with a cluster that has fields that are not modelled by the client.
+const testGetClusterExtraFieldsResponseRaw = `{"cluster": ` + testGetClusterExtraFieldsRaw + `}`
+
+// testFilterClustersResponseRaw represents a raw response from the List request
+// with clusters that differ in filtered fields.
+const testFilterClustersResponseRaw = `
+{
+    "clusters": [
+        {
+            "id": "0a5b5d5e-1c3f-4b2a-9d8e-0e1f2a3b4c01",
+            "name": "prod-payments-1",
+            "status": "ACTIVE",
+            "region": "ru-1",
+            "kube_version": "1.28.5",
+            "cni_type": "CALICO",
+            "created_at": "2023-03-01T10:00:00Z",
+            "updated_at": "2023-05-01T10:00:00Z"
+        },
+        {
+            "id": "0a5b5d5e-1c3f-4b2a-9d8e-0e1f2a3b4c02",
+            "name": "staging-payments-1",
+            "status": "PENDING_RESIZE",
+            "region": "ru-1",
+            "kube_version": "1.27.8",
+            "cni_type": "CILIUM",
+            "created_at": "2023-01-01T10:00:00Z",
+            "updated_at": "2023-06-01T10:00:00Z"
+        },
+        {
+            "id": "0a5b5d5e-1c3f-4b2a-9d8e-0e1f2a3b4c03",
+            "name": "prod-search-1",
+            "status": "ACTIVE",
+            "region": "ru-7",
+            "kube_version": "1.28.3",
+            "cni_type": "CILIUM",
+            "created_at": "2023-02-01T10:00:00Z"
+        },
+        {
+            "id": "0a5b5d5e-1c3f-4b2a-9d8e-0e1f2a3b4c04",
+            "name": "production-legacy",
+            "status": "ERROR",
+            "region": "ru-1",
+            "kube_version": "1.26.9",
+            "cni_type": "CALICO",
+            "created_at": "2022-12-01T10:00:00Z",
+            "updated_at": "2023-04-01T10:00:00Z"
+        }
+    ]
+}
+`
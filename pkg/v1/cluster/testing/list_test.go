@@ -0,0 +1,150 @@
+package testing
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/selectel/mks-go/pkg/testutils"
+	v1 "github.com/selectel/mks-go/pkg/v1"
+	"github.com/selectel/mks-go/pkg/v1/cluster"
+)
+
+// filteredClusterNames returns names of the clusters that match the options.
+func filteredClusterNames(t *testing.T, opts *cluster.ListOpts) []string {
+	t.Helper()
+
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	endpointCalled := false
+	testutils.HandleReqWithoutBody(t, &testutils.HandleReqOpts{
+		Mux:         testEnv.Mux,
+		URL:         "/v1/clusters",
+		RawResponse: testFilterClustersResponseRaw,
+		Method:      http.MethodGet,
+		Status:      http.StatusOK,
+		CallFlag:    &endpointCalled,
+	})
+
+	ctx := context.Background()
+	testClient := newUpgradeTestClient(testEnv)
+
+	clusters, _, err := cluster.ListWithOpts(ctx, testClient, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !endpointCalled {
+		t.Fatal("endpoint wasn't called")
+	}
+
+	names := make([]string, 0, len(clusters))
+	for _, clusterView := range clusters {
+		names = append(names, clusterView.Name)
+	}
+
+	return names
+}
+
+func TestListWithOpts(t *testing.T) {
+	testCases := []struct {
+		name     string
+		opts     *cluster.ListOpts
+		expected []string
+	}{
+		{
+			name:     "no filters",
+			opts:     &cluster.ListOpts{},
+			expected: []string{"prod-payments-1", "staging-payments-1", "prod-search-1", "production-legacy"},
+		},
+		{
+			name:     "nil options",
+			opts:     nil,
+			expected: []string{"prod-payments-1", "staging-payments-1", "prod-search-1", "production-legacy"},
+		},
+		{
+			name:     "exact name",
+			opts:     &cluster.ListOpts{Name: "prod-search-1"},
+			expected: []string{"prod-search-1"},
+		},
+		{
+			name:     "glob name",
+			opts:     &cluster.ListOpts{Name: "*-payments-*"},
+			expected: []string{"prod-payments-1", "staging-payments-1"},
+		},
+		{
+			name:     "statuses",
+			opts:     &cluster.ListOpts{Statuses: []cluster.Status{cluster.StatusPendingResize, cluster.StatusError}},
+			expected: []string{"staging-payments-1", "production-legacy"},
+		},
+		{
+			name:     "region and CNI type",
+			opts:     &cluster.ListOpts{Region: "ru-1", CNIType: cluster.CNITypeCalico},
+			expected: []string{"prod-payments-1", "production-legacy"},
+		},
+		{
+			name:     "minor kube version",
+			opts:     &cluster.ListOpts{KubeVersion: "1.28"},
+			expected: []string{"prod-payments-1", "prod-search-1"},
+		},
+		{
+			name:     "exact kube version",
+			opts:     &cluster.ListOpts{KubeVersion: "1.28.3"},
+			expected: []string{"prod-search-1"},
+		},
+		{
+			name:     "label",
+			opts:     &cluster.ListOpts{Label: "prod"},
+			expected: []string{"prod-payments-1", "prod-search-1"},
+		},
+		{
+			name: "custom name labels",
+			opts: &cluster.ListOpts{
+				Label: "prod",
+				NameLabels: func(name string) []string {
+					return []string{strings.TrimSuffix(strings.SplitN(name, "-", 2)[0], "uction")}
+				},
+			},
+			expected: []string{"prod-payments-1", "prod-search-1", "production-legacy"},
+		},
+		{
+			name:     "sort by created at",
+			opts:     &cluster.ListOpts{SortBy: cluster.ListSortByCreatedAt},
+			expected: []string{"production-legacy", "staging-payments-1", "prod-search-1", "prod-payments-1"},
+		},
+		{
+			name:     "sort by updated at descending",
+			opts:     &cluster.ListOpts{SortBy: cluster.ListSortByUpdatedAt, SortDescending: true},
+			expected: []string{"staging-payments-1", "prod-payments-1", "production-legacy", "prod-search-1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		actual := filteredClusterNames(t, tc.opts)
+		if !reflect.DeepEqual(tc.expected, actual) {
+			t.Fatalf("%s: expected %v, but got %v", tc.name, tc.expected, actual)
+		}
+	}
+}
+
+func TestListWithOptsInvalid(t *testing.T) {
+	ctx := context.Background()
+	testClient := &v1.ServiceClient{
+		HTTPClient: &http.Client{},
+		TokenID:    testutils.TokenID,
+		Endpoint:   "http://127.0.0.1:0/v1",
+		UserAgent:  testutils.UserAgent,
+	}
+
+	for _, opts := range []*cluster.ListOpts{
+		{Name: "prod-["},
+		{SortBy: "name"},
+	} {
+		_, _, err := cluster.ListWithOpts(ctx, testClient, opts)
+		if err == nil {
+			t.Fatalf("expected error for %#v options", opts)
+		}
+	}
+}
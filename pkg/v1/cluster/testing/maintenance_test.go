@@ -0,0 +1,193 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/selectel/mks-go/pkg/testutils"
+	"github.com/selectel/mks-go/pkg/v1/cluster"
+)
+
+func TestParseMaintenanceWindow(t *testing.T) {
+	window, err := cluster.ParseMaintenanceWindow("01:00:00", "03:30:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if window.Start != time.Hour || window.End != 3*time.Hour+30*time.Minute {
+		t.Fatalf("unexpected window: %#v", window)
+	}
+	if window.CrossesMidnight() {
+		t.Fatal("expected window that doesn't cross midnight")
+	}
+	if window.Duration() != 2*time.Hour+30*time.Minute {
+		t.Fatalf("expected 2h30m duration, but got %s", window.Duration())
+	}
+	if window.String() != "01:00:00-03:30:00" {
+		t.Fatalf("expected 01:00:00-03:30:00, but got %s", window.String())
+	}
+}
+
+func TestParseMaintenanceWindowInvalid(t *testing.T) {
+	for _, tc := range [][2]string{
+		{"", "03:00:00"},
+		{"01:00:00", ""},
+		{"25:00:00", "03:00:00"},
+		{"01:00", "03:00:00"},
+		{"01:00:00", "01:00:00"},
+	} {
+		_, err := cluster.ParseMaintenanceWindow(tc[0], tc[1])
+		if !errors.Is(err, cluster.ErrInvalidMaintenanceWindow) {
+			t.Fatalf("expected ErrInvalidMaintenanceWindow for %v, but got %v", tc, err)
+		}
+	}
+}
+
+func TestBaseViewMaintenanceWindow(t *testing.T) {
+	window, err := expectedGetClusterResponse.MaintenanceWindow()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if window.String() != "01:00:00-03:00:00" {
+		t.Fatalf("expected 01:00:00-03:00:00, but got %s", window.String())
+	}
+}
+
+func TestMaintenanceWindowSchedule(t *testing.T) {
+	window, err := cluster.ParseMaintenanceWindow("01:00:00", "03:00:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		now      time.Time
+		contains bool
+		next     time.Time
+		until    time.Duration
+	}{
+		{
+			now:   time.Date(2020, 2, 13, 0, 30, 0, 0, time.UTC),
+			next:  time.Date(2020, 2, 13, 1, 0, 0, 0, time.UTC),
+			until: 30 * time.Minute,
+		},
+		{
+			now:      time.Date(2020, 2, 13, 1, 0, 0, 0, time.UTC),
+			contains: true,
+			next:     time.Date(2020, 2, 14, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			now:   time.Date(2020, 2, 13, 3, 0, 0, 0, time.UTC),
+			next:  time.Date(2020, 2, 14, 1, 0, 0, 0, time.UTC),
+			until: 22 * time.Hour,
+		},
+		{
+			now:   time.Date(2020, 2, 13, 3, 0, 0, 0, time.FixedZone("UTC+3", 3*60*60)),
+			next:  time.Date(2020, 2, 13, 1, 0, 0, 0, time.UTC),
+			until: time.Hour,
+		},
+	}
+	for _, tc := range testCases {
+		if window.Contains(tc.now) != tc.contains {
+			t.Fatalf("expected Contains(%s) to be %v", tc.now, tc.contains)
+		}
+		if next := window.Next(tc.now); !next.Equal(tc.next) {
+			t.Fatalf("expected Next(%s) to be %s, but got %s", tc.now, tc.next, next)
+		}
+		if until := window.Until(tc.now); until != tc.until {
+			t.Fatalf("expected Until(%s) to be %s, but got %s", tc.now, tc.until, until)
+		}
+	}
+}
+
+func TestMaintenanceWindowCrossingMidnight(t *testing.T) {
+	window, err := cluster.ParseMaintenanceWindow("23:00:00", "02:00:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !window.CrossesMidnight() {
+		t.Fatal("expected window that crosses midnight")
+	}
+	if window.Duration() != 3*time.Hour {
+		t.Fatalf("expected 3h duration, but got %s", window.Duration())
+	}
+
+	for _, tc := range []struct {
+		now      time.Time
+		contains bool
+	}{
+		{now: time.Date(2020, 2, 13, 22, 59, 59, 0, time.UTC)},
+		{now: time.Date(2020, 2, 13, 23, 0, 0, 0, time.UTC), contains: true},
+		{now: time.Date(2020, 2, 14, 0, 0, 0, 0, time.UTC), contains: true},
+		{now: time.Date(2020, 2, 14, 1, 59, 59, 0, time.UTC), contains: true},
+		{now: time.Date(2020, 2, 14, 2, 0, 0, 0, time.UTC)},
+	} {
+		if window.Contains(tc.now) != tc.contains {
+			t.Fatalf("expected Contains(%s) to be %v", tc.now, tc.contains)
+		}
+	}
+
+	now := time.Date(2020, 2, 14, 2, 0, 0, 0, time.UTC)
+	expectedNext := time.Date(2020, 2, 14, 23, 0, 0, 0, time.UTC)
+	if next := window.Next(now); !next.Equal(expectedNext) {
+		t.Fatalf("expected Next(%s) to be %s, but got %s", now, expectedNext, next)
+	}
+}
+
+// handleMaintenanceWindowCluster registers a cluster handler with the provided maintenance window.
+func handleMaintenanceWindowCluster(mux *http.ServeMux, start, end time.Time) {
+	mux.HandleFunc("/v1/clusters/"+upgradeClusterID, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"cluster": {"id": "%s", "status": "ACTIVE", "maintenance_window_start": "%s", "maintenance_window_end": "%s"}}`,
+			upgradeClusterID, start.UTC().Format("15:04:05"), end.UTC().Format("15:04:05"))
+	})
+}
+
+func TestRunInWindowOpen(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	now := time.Now()
+	handleMaintenanceWindowCluster(testEnv.Mux, now.Add(-time.Hour), now.Add(time.Hour))
+
+	ctx := context.Background()
+	testClient := newUpgradeTestClient(testEnv)
+
+	called := false
+	err := cluster.RunInWindow(ctx, testClient, upgradeClusterID, func(ctx context.Context) error {
+		called = true
+
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !called {
+		t.Fatal("function wasn't called")
+	}
+}
+
+func TestRunInWindowCancelledContext(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	now := time.Now()
+	handleMaintenanceWindowCluster(testEnv.Mux, now.Add(2*time.Hour), now.Add(3*time.Hour))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	testClient := newUpgradeTestClient(testEnv)
+
+	called := false
+	err := cluster.RunInWindow(ctx, testClient, upgradeClusterID, func(ctx context.Context) error {
+		called = true
+
+		return nil
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, but got %v", err)
+	}
+	if called {
+		t.Fatal("function was called outside of the maintenance window")
+	}
+}
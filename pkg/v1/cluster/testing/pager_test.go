@@ -0,0 +1,43 @@
+package testing
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/selectel/mks-go/pkg/testutils"
+	"github.com/selectel/mks-go/pkg/v1/cluster"
+)
+
+func TestClusterPager(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	var queries []string
+	testEnv.Mux.HandleFunc("/v1/clusters", func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(testListClustersResponseRaw))
+	})
+
+	ctx := context.Background()
+	testClient := newUpgradeTestClient(testEnv)
+
+	// The API returns all clusters ignoring the limit.
+	pager := cluster.NewPager(testClient, 5)
+	var actual []*cluster.ListView
+	for pager.Next(ctx) {
+		actual = append(actual, pager.Current())
+	}
+	if err := pager.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expectedListClustersResponse, actual) {
+		t.Fatalf("expected %#v, but got %#v", expectedListClustersResponse, actual)
+	}
+	if !reflect.DeepEqual([]string{"limit=5"}, queries) {
+		t.Fatalf("expected a single request with the limit, but got %v", queries)
+	}
+}
@@ -0,0 +1,153 @@
+package testing
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/selectel/mks-go/pkg/testutils"
+	v1 "github.com/selectel/mks-go/pkg/v1"
+	"github.com/selectel/mks-go/pkg/v1/cluster"
+)
+
+func TestExportSpec(t *testing.T) {
+	clusterEndpointCalled := false
+	nodegroupsEndpointCalled := false
+	nodegroupEndpointCalled := false
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	testutils.HandleReqWithoutBody(t, &testutils.HandleReqOpts{
+		Mux:         testEnv.Mux,
+		URL:         "/v1/clusters/dbe7559b-55d8-4f65-9230-6a22b985ff73",
+		RawResponse: testGetClusterResponseRaw,
+		Method:      http.MethodGet,
+		Status:      http.StatusOK,
+		CallFlag:    &clusterEndpointCalled,
+	})
+	testutils.HandleReqWithoutBody(t, &testutils.HandleReqOpts{
+		Mux:         testEnv.Mux,
+		URL:         "/v1/clusters/dbe7559b-55d8-4f65-9230-6a22b985ff73/nodegroups",
+		RawResponse: testListClusterNodegroupsResponseRaw,
+		Method:      http.MethodGet,
+		Status:      http.StatusOK,
+		CallFlag:    &nodegroupsEndpointCalled,
+	})
+	testutils.HandleReqWithoutBody(t, &testutils.HandleReqOpts{
+		Mux:         testEnv.Mux,
+		URL:         "/v1/clusters/dbe7559b-55d8-4f65-9230-6a22b985ff73/nodegroups/a376745a-fbcb-413d-b418-169d059d79ce",
+		RawResponse: testGetClusterNodegroupResponseRaw,
+		Method:      http.MethodGet,
+		Status:      http.StatusOK,
+		CallFlag:    &nodegroupEndpointCalled,
+	})
+
+	ctx := context.Background()
+	testClient := &v1.ServiceClient{
+		HTTPClient: &http.Client{},
+		TokenID:    testutils.TokenID,
+		Endpoint:   testEnv.Server.URL + "/v1",
+		UserAgent:  testutils.UserAgent,
+	}
+	id := "dbe7559b-55d8-4f65-9230-6a22b985ff73"
+
+	actual, err := cluster.ExportSpec(ctx, testClient, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !clusterEndpointCalled || !nodegroupsEndpointCalled || !nodegroupEndpointCalled {
+		t.Fatal("endpoint wasn't called")
+	}
+	if !reflect.DeepEqual(expectedClusterSpec, actual) {
+		t.Fatalf("expected %#v, but got %#v", expectedClusterSpec, actual)
+	}
+}
+
+func TestExportSpecHTTPError(t *testing.T) {
+	endpointCalled := false
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	testutils.HandleReqWithoutBody(t, &testutils.HandleReqOpts{
+		Mux:         testEnv.Mux,
+		URL:         "/v1/clusters/dbe7559b-55d8-4f65-9230-6a22b985ff73",
+		RawResponse: testErrGenericResponseRaw,
+		Method:      http.MethodGet,
+		Status:      http.StatusBadGateway,
+		CallFlag:    &endpointCalled,
+	})
+
+	ctx := context.Background()
+	testClient := &v1.ServiceClient{
+		HTTPClient: &http.Client{},
+		TokenID:    testutils.TokenID,
+		Endpoint:   testEnv.Server.URL + "/v1",
+		UserAgent:  testutils.UserAgent,
+	}
+	id := "dbe7559b-55d8-4f65-9230-6a22b985ff73"
+
+	actual, err := cluster.ExportSpec(ctx, testClient, id)
+
+	if !endpointCalled {
+		t.Fatal("endpoint wasn't called")
+	}
+	if actual != nil {
+		t.Fatal("expected no spec from the ExportSpec method")
+	}
+	if err == nil {
+		t.Fatal("expected error from the ExportSpec method")
+	}
+}
+
+func TestSpecYAMLRoundTrip(t *testing.T) {
+	data, err := expectedClusterSpec.YAML()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual, err := cluster.ParseSpec(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expectedClusterSpec, actual) {
+		t.Fatalf("expected %#v, but got %#v", expectedClusterSpec, actual)
+	}
+}
+
+func TestSpecJSONRoundTrip(t *testing.T) {
+	data, err := expectedClusterSpec.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual, err := cluster.ParseSpec(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expectedClusterSpec, actual) {
+		t.Fatalf("expected %#v, but got %#v", expectedClusterSpec, actual)
+	}
+}
+
+func TestSpecCreateOpts(t *testing.T) {
+	spec, err := cluster.ParseSpec([]byte(testClusterSpecYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual := spec.CreateOpts()
+	if !reflect.DeepEqual(expectedClusterSpecCreateOpts, actual) {
+		t.Fatalf("expected %#v, but got %#v", expectedClusterSpecCreateOpts, actual)
+	}
+}
+
+func TestParseSpecInvalid(t *testing.T) {
+	actual, err := cluster.ParseSpec([]byte("name: [test"))
+	if err == nil {
+		t.Fatal("expected error from the ParseSpec method")
+	}
+	if actual != nil {
+		t.Fatal("expected no spec from the ParseSpec method")
+	}
+}
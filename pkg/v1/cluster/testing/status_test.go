@@ -0,0 +1,61 @@
+package testing
+
+import (
+	"testing"
+
+	"github.com/selectel/mks-go/pkg/v1/cluster"
+)
+
+func TestStatusClassification(t *testing.T) {
+	testCases := []struct {
+		status   cluster.Status
+		stable   bool
+		isError  bool
+		pending  bool
+		terminal bool
+	}{
+		{status: cluster.StatusActive, stable: true, terminal: true},
+		{status: cluster.StatusError, isError: true, terminal: true},
+		{status: cluster.StatusPendingResize, pending: true},
+		{status: cluster.StatusPendingUpgradeMinorVersion, pending: true},
+		{status: cluster.StatusMaintenance, pending: true},
+		{status: cluster.StatusUnknown},
+	}
+	for _, tc := range testCases {
+		if tc.status.IsStable() != tc.stable {
+			t.Fatalf("expected IsStable of %s to be %v", tc.status, tc.stable)
+		}
+		if tc.status.IsError() != tc.isError {
+			t.Fatalf("expected IsError of %s to be %v", tc.status, tc.isError)
+		}
+		if tc.status.IsPending() != tc.pending {
+			t.Fatalf("expected IsPending of %s to be %v", tc.status, tc.pending)
+		}
+		if tc.status.IsTerminal() != tc.terminal {
+			t.Fatalf("expected IsTerminal of %s to be %v", tc.status, tc.terminal)
+		}
+	}
+}
+
+func TestStatusCanPerform(t *testing.T) {
+	testCases := []struct {
+		status    cluster.Status
+		operation cluster.Operation
+		expected  bool
+	}{
+		{status: cluster.StatusActive, operation: cluster.OperationUpdate, expected: true},
+		{status: cluster.StatusActive, operation: cluster.OperationUpgradeMinorVersion, expected: true},
+		{status: cluster.StatusPendingResize, operation: cluster.OperationRotateCerts, expected: false},
+		{status: cluster.StatusPendingResize, operation: cluster.OperationDelete, expected: true},
+		{status: cluster.StatusPendingDelete, operation: cluster.OperationDelete, expected: false},
+		{status: cluster.StatusError, operation: cluster.OperationRotateCerts, expected: false},
+		{status: cluster.StatusError, operation: cluster.OperationDelete, expected: true},
+		{status: cluster.StatusUnknown, operation: cluster.OperationUpdate, expected: true},
+		{status: cluster.Status("NEW_STATUS"), operation: cluster.OperationUpdate, expected: true},
+	}
+	for _, tc := range testCases {
+		if actual := tc.status.CanPerform(tc.operation); actual != tc.expected {
+			t.Fatalf("expected CanPerform(%s) of %s to be %v", tc.operation, tc.status, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package testing
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/selectel/mks-go/pkg/testutils"
+	"github.com/selectel/mks-go/pkg/v1/cluster"
+	"github.com/selectel/mks-go/pkg/v1/task"
+)
+
+func TestRotateCertsAndTrack(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	fakeTasks := testutils.HandleFakeTasks(testEnv.Mux, upgradeClusterID)
+	fakeTasks.Add("rotate-old", string(task.TypeRotateCerts), string(task.StatusDone), "")
+	testEnv.Mux.HandleFunc("/v1/clusters/"+upgradeClusterID+"/rotate-certs", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("expected %s method but got %s", http.MethodPost, r.Method)
+		}
+		fakeTasks.Add("rotate-new", string(task.TypeRotateCerts), string(task.StatusDone), "")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	testClient := newUpgradeTestClient(testEnv)
+
+	operation, _, err := cluster.RotateCertsAndTrack(ctx, testClient, upgradeClusterID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	operation.PollInterval = time.Millisecond
+
+	actual, err := operation.Wait(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual.ID != "rotate-new" {
+		t.Fatalf("expected rotate-new task, but got %s", actual.ID)
+	}
+}
+
+func TestDeleteAndTrack(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	fakeTasks := testutils.HandleFakeTasks(testEnv.Mux, upgradeClusterID)
+	testEnv.Mux.HandleFunc("/v1/clusters/"+upgradeClusterID, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Fatalf("expected %s method but got %s", http.MethodDelete, r.Method)
+		}
+		fakeTasks.Add("delete", string(task.TypeDeleteCluster), string(task.StatusInProgress), "")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	testClient := newUpgradeTestClient(testEnv)
+
+	operation, _, err := cluster.DeleteAndTrack(ctx, testClient, upgradeClusterID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	operation.PollInterval = time.Millisecond
+
+	deleteTask, err := operation.Task(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleteTask.ID != "delete" {
+		t.Fatalf("expected delete task, but got %s", deleteTask.ID)
+	}
+
+	// The cluster with its tasks disappears after the deletion.
+	fakeTasks.SetNotFound(true)
+	actual, err := operation.Wait(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual != nil {
+		t.Fatalf("expected no task for the deleted cluster, but got %#v", actual)
+	}
+}
@@ -0,0 +1,275 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/selectel/mks-go/pkg/testutils"
+	v1 "github.com/selectel/mks-go/pkg/v1"
+	"github.com/selectel/mks-go/pkg/v1/cluster"
+	"github.com/selectel/mks-go/pkg/v1/task"
+)
+
+const upgradeClusterID = "ab1f1e5c-6a0e-4b2b-bb1c-1d5b7c0f1c2e"
+
+// fakeUpgradeAPI emulates cluster upgrades of the MKS API.
+type fakeUpgradeAPI struct {
+	mu          sync.Mutex
+	status      string
+	kubeVersion string
+	taskStatus  string
+	tasks       []string
+	upgrades    []string
+
+	// clusterReads counts cluster reads since the latest upgrade request.
+	clusterReads int
+
+	// beforeSecondStep is called when tasks are listed after the first upgrade has been finished.
+	beforeSecondStep func()
+}
+
+func newFakeUpgradeAPI(t *testing.T, mux *http.ServeMux, status, kubeVersion, taskStatus string) *fakeUpgradeAPI {
+	api := &fakeUpgradeAPI{
+		status:      status,
+		kubeVersion: kubeVersion,
+		taskStatus:  taskStatus,
+	}
+	clusterURL := "/v1/clusters/" + upgradeClusterID
+
+	mux.HandleFunc("/v1/kubeversions", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, testUpgradeKubeVersionsResponseRaw)
+	})
+	mux.HandleFunc(clusterURL, func(w http.ResponseWriter, r *http.Request) {
+		api.mu.Lock()
+		defer api.mu.Unlock()
+		api.clusterReads++
+		api.writeCluster(w)
+	})
+	mux.HandleFunc(clusterURL+"/upgrade-patch-version", func(w http.ResponseWriter, r *http.Request) {
+		api.upgrade(t, w, r, testUpgradePatchVersions, string(task.TypeUpgradePatchVersion))
+	})
+	mux.HandleFunc(clusterURL+"/upgrade-minor-version", func(w http.ResponseWriter, r *http.Request) {
+		api.upgrade(t, w, r, testUpgradeMinorVersions, string(task.TypeUpgradeMinorVersion))
+	})
+	mux.HandleFunc(clusterURL+"/tasks", func(w http.ResponseWriter, r *http.Request) {
+		api.mu.Lock()
+		defer api.mu.Unlock()
+		if len(api.upgrades) == 1 && api.clusterReads > 0 && api.beforeSecondStep != nil {
+			api.beforeSecondStep()
+		}
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"tasks": [%s]}`, strings.Join(api.tasks, ","))
+	})
+	mux.HandleFunc(clusterURL+"/tasks/", func(w http.ResponseWriter, r *http.Request) {
+		api.mu.Lock()
+		defer api.mu.Unlock()
+		taskID := strings.TrimPrefix(r.URL.Path, clusterURL+"/tasks/")
+		for _, rawTask := range api.tasks {
+			if strings.Contains(rawTask, taskID) {
+				w.Header().Add("Content-Type", "application/json")
+				fmt.Fprintf(w, `{"task": %s}`, rawTask)
+
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	return api
+}
+
+func (api *fakeUpgradeAPI) upgrade(t *testing.T, w http.ResponseWriter, r *http.Request, targets map[string]string, taskType string) {
+	if r.Method != http.MethodPost {
+		t.Fatalf("expected %s method but got %s", http.MethodPost, r.Method)
+	}
+
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	api.upgrades = append(api.upgrades, taskType)
+	api.clusterReads = 0
+	if target, ok := targets[api.kubeVersion]; ok && api.taskStatus == string(task.StatusDone) {
+		api.kubeVersion = target
+	}
+	startedAt := time.Now().UTC().Format(time.RFC3339Nano)
+	api.tasks = append(api.tasks, fmt.Sprintf(`{
+        "id": "task-%d",
+        "cluster_id": "%s",
+        "started_at": "%s",
+        "updated_at": "%s",
+        "status": "%s",
+        "type": "%s"
+    }`, len(api.tasks), upgradeClusterID, startedAt, startedAt, api.taskStatus, taskType))
+	api.writeCluster(w)
+}
+
+func (api *fakeUpgradeAPI) writeCluster(w http.ResponseWriter) {
+	w.Header().Add("Content-Type", "application/json")
+	fmt.Fprintf(w, `{"cluster": {"id": "%s", "status": "%s", "kube_version": "%s"}}`,
+		upgradeClusterID, api.status, api.kubeVersion)
+}
+
+func (api *fakeUpgradeAPI) upgradeRequests() []string {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	return append([]string{}, api.upgrades...)
+}
+
+func newUpgradeTestClient(testEnv *testutils.TestEnv) *v1.ServiceClient {
+	return &v1.ServiceClient{
+		HTTPClient: &http.Client{},
+		TokenID:    testutils.TokenID,
+		Endpoint:   testEnv.Server.URL + "/v1",
+		UserAgent:  testutils.UserAgent,
+	}
+}
+
+func TestUpgradeTo(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	api := newFakeUpgradeAPI(t, testEnv.Mux, "ACTIVE", "1.26.3", "DONE")
+
+	ctx := context.Background()
+	testClient := newUpgradeTestClient(testEnv)
+	progress := make(chan cluster.UpgradeEvent)
+
+	var events []cluster.UpgradeEvent
+	done := make(chan struct{})
+	go func() {
+		for event := range progress {
+			events = append(events, event)
+		}
+		close(done)
+	}()
+
+	actual, err := cluster.UpgradeTo(ctx, testClient, upgradeClusterID, "1.28.5", &cluster.UpgradeOpts{
+		PollInterval: time.Millisecond,
+		Progress:     progress,
+	})
+	close(progress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-done
+
+	if actual.KubeVersion != "1.28.5" {
+		t.Fatalf("expected cluster with 1.28.5 version, but got %s", actual.KubeVersion)
+	}
+	expectedUpgrades := []string{
+		string(task.TypeUpgradePatchVersion),
+		string(task.TypeUpgradeMinorVersion),
+		string(task.TypeUpgradeMinorVersion),
+	}
+	if actualUpgrades := api.upgradeRequests(); strings.Join(actualUpgrades, ",") != strings.Join(expectedUpgrades, ",") {
+		t.Fatalf("expected %v upgrades, but got %v", expectedUpgrades, actualUpgrades)
+	}
+
+	expectedEvents := []cluster.UpgradeEventType{cluster.UpgradeEventPlanned}
+	for range expectedUpgrades {
+		expectedEvents = append(expectedEvents,
+			cluster.UpgradeEventStepStarted, cluster.UpgradeEventTaskStarted, cluster.UpgradeEventStepFinished)
+	}
+	expectedEvents = append(expectedEvents, cluster.UpgradeEventFinished)
+	if len(events) != len(expectedEvents) {
+		t.Fatalf("expected %d events, but got %d", len(expectedEvents), len(events))
+	}
+	for i, event := range events {
+		if event.Type != expectedEvents[i] {
+			t.Fatalf("expected %s event at %d position, but got %s", expectedEvents[i], i, event.Type)
+		}
+	}
+	if len(events[0].Steps) != 3 {
+		t.Fatalf("expected 3 planned steps, but got %d", len(events[0].Steps))
+	}
+}
+
+func TestUpgradeToNotActive(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	api := newFakeUpgradeAPI(t, testEnv.Mux, "PENDING_RESIZE", "1.26.3", "DONE")
+
+	ctx := context.Background()
+	testClient := newUpgradeTestClient(testEnv)
+
+	_, err := cluster.UpgradeTo(ctx, testClient, upgradeClusterID, "", nil)
+	if !errors.Is(err, cluster.ErrClusterNotActive) {
+		t.Fatalf("expected ErrClusterNotActive, but got %v", err)
+	}
+	if upgrades := api.upgradeRequests(); len(upgrades) != 0 {
+		t.Fatalf("expected no upgrades, but got %v", upgrades)
+	}
+}
+
+func TestUpgradeToReusedOpts(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	newFakeUpgradeAPI(t, testEnv.Mux, "PENDING_RESIZE", "1.26.3", "DONE")
+
+	ctx := context.Background()
+	testClient := newUpgradeTestClient(testEnv)
+	opts := &cluster.UpgradeOpts{
+		Progress: make(chan cluster.UpgradeEvent, 1),
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := cluster.UpgradeTo(ctx, testClient, upgradeClusterID, "", opts)
+		if !errors.Is(err, cluster.ErrClusterNotActive) {
+			t.Fatalf("expected ErrClusterNotActive, but got %v", err)
+		}
+	}
+}
+
+func TestUpgradeToTaskError(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	api := newFakeUpgradeAPI(t, testEnv.Mux, "ACTIVE", "1.26.3", "ERROR")
+
+	ctx := context.Background()
+	testClient := newUpgradeTestClient(testEnv)
+
+	_, err := cluster.UpgradeTo(ctx, testClient, upgradeClusterID, "", &cluster.UpgradeOpts{
+		PollInterval: time.Millisecond,
+	})
+	if !errors.Is(err, task.ErrTaskFailed) {
+		t.Fatalf("expected ErrTaskFailed, but got %v", err)
+	}
+	if upgrades := api.upgradeRequests(); len(upgrades) != 1 {
+		t.Fatalf("expected a single upgrade, but got %v", upgrades)
+	}
+}
+
+func TestUpgradeToCancelledContext(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	api := newFakeUpgradeAPI(t, testEnv.Mux, "ACTIVE", "1.26.3", "DONE")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	testClient := newUpgradeTestClient(testEnv)
+
+	// Cancel the context after the first step has been finished.
+	api.mu.Lock()
+	api.beforeSecondStep = cancel
+	api.mu.Unlock()
+
+	actual, err := cluster.UpgradeTo(ctx, testClient, upgradeClusterID, "", &cluster.UpgradeOpts{
+		PollInterval: time.Millisecond,
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, but got %v", err)
+	}
+	if actual == nil || actual.KubeVersion != "1.26.9" {
+		t.Fatalf("expected cluster with 1.26.9 version, but got %#v", actual)
+	}
+	if upgrades := api.upgradeRequests(); len(upgrades) != 1 {
+		t.Fatalf("expected a single upgrade, but got %v", upgrades)
+	}
+}
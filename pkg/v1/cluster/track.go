@@ -0,0 +1,43 @@
+package cluster
+
+import (
+	"context"
+
+	v1 "github.com/selectel/mks-go/pkg/v1"
+	"github.com/selectel/mks-go/pkg/v1/task"
+)
+
+// RotateCertsAndTrack requests a rotation of cluster certificates by cluster id and returns
+// an operation that tracks the spawned task.
+func RotateCertsAndTrack(ctx context.Context, client *v1.ServiceClient, clusterID string) (*task.Operation, *v1.ResponseResult, error) {
+	operation, err := task.NewOperation(ctx, client, clusterID, task.TypeRotateCerts, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responseResult, err := RotateCerts(ctx, client, clusterID)
+	if err != nil {
+		return nil, responseResult, err
+	}
+	operation.SetResponse(responseResult)
+
+	return operation, responseResult, nil
+}
+
+// DeleteAndTrack deletes a single cluster by its id and returns an operation that tracks
+// the spawned task. The operation is finished when the cluster can't be found anymore.
+func DeleteAndTrack(ctx context.Context, client *v1.ServiceClient, clusterID string) (*task.Operation, *v1.ResponseResult, error) {
+	operation, err := task.NewOperation(ctx, client, clusterID, task.TypeDeleteCluster, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	operation.CompleteOnNotFound = true
+
+	responseResult, err := Delete(ctx, client, clusterID)
+	if err != nil {
+		return nil, responseResult, err
+	}
+	operation.SetResponse(responseResult)
+
+	return operation, responseResult, nil
+}
@@ -0,0 +1,186 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	v1 "github.com/selectel/mks-go/pkg/v1"
+	"github.com/selectel/mks-go/pkg/v1/kubeversion"
+	"github.com/selectel/mks-go/pkg/v1/task"
+)
+
+var (
+	// ErrClusterNotActive is returned when an operation requires a cluster with the active status.
+	ErrClusterNotActive = errors.New("cluster doesn't have the active status")
+
+	// ErrUpgradeNotApplied is returned when a cluster has the same Kubernetes version after the upgrade.
+	ErrUpgradeNotApplied = errors.New("cluster Kubernetes version hasn't been changed by the upgrade")
+)
+
+// UpgradeEventType represents custom type for upgrade progress events.
+type UpgradeEventType string
+
+const (
+	// UpgradeEventPlanned is sent after the upgrade steps have been computed.
+	UpgradeEventPlanned UpgradeEventType = "PLANNED"
+
+	// UpgradeEventStepStarted is sent after the upgrade request has been accepted.
+	UpgradeEventStepStarted UpgradeEventType = "STEP_STARTED"
+
+	// UpgradeEventTaskStarted is sent when the upgrade task of the step has been found.
+	UpgradeEventTaskStarted UpgradeEventType = "TASK_STARTED"
+
+	// UpgradeEventStepFinished is sent when the upgrade task is finished and the cluster is active.
+	UpgradeEventStepFinished UpgradeEventType = "STEP_FINISHED"
+
+	// UpgradeEventFinished is sent when the cluster has the target Kubernetes version.
+	UpgradeEventFinished UpgradeEventType = "FINISHED"
+)
+
+// UpgradeEvent represents a progress event of the UpgradeTo workflow.
+type UpgradeEvent struct {
+	// Type represents the type of the event.
+	Type UpgradeEventType
+
+	// Steps contains the remaining upgrade steps. It's set for UpgradeEventPlanned events.
+	Steps []*kubeversion.UpgradeStep
+
+	// Step represents the current upgrade step. It's set for step events.
+	Step *kubeversion.UpgradeStep
+
+	// TaskID contains the id of the upgrade task. It's set for task and step finished events.
+	TaskID string
+
+	// Cluster represents the latest known state of the cluster.
+	Cluster *GetView
+}
+
+// UpgradeOpts represents options for the UpgradeTo workflow.
+type UpgradeOpts struct {
+	// PollInterval represents the interval between requests when waiting for tasks and the cluster.
+	// task.DefaultPollInterval is used if it's not set.
+	PollInterval time.Duration
+
+	// Progress receives progress events if it's set. Sends are blocking so the channel
+	// should be read until UpgradeTo returns. The channel isn't closed by UpgradeTo,
+	// the caller closes it so the same options can be reused for several upgrades.
+	Progress chan<- UpgradeEvent
+}
+
+// UpgradeTo upgrades a cluster referenced by its id to the target Kubernetes version
+// running UpgradePatchVersion and UpgradeMinorVersion requests in sequence.
+// The latest available version is used if the target version is empty.
+//
+// The cluster needs to have the active status. After each step UpgradeTo waits for
+// the upgrade task to finish and for the cluster to become active.
+// Context cancellation is checked between the steps, an already started step is
+// continued by the API even if UpgradeTo returns because of the cancelled context.
+func UpgradeTo(ctx context.Context, client *v1.ServiceClient, clusterID, targetVersion string, opts *UpgradeOpts) (*GetView, error) {
+	if opts == nil {
+		opts = &UpgradeOpts{}
+	}
+
+	versions, _, err := kubeversion.List(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	mksCluster, _, err := Get(ctx, client, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	if mksCluster.Status != StatusActive {
+		return mksCluster, fmt.Errorf("%w: %s has the %s status", ErrClusterNotActive, mksCluster.ID, mksCluster.Status)
+	}
+
+	steps, err := kubeversion.PlanUpgrade(mksCluster.KubeVersion, versions, targetVersion)
+	if err != nil {
+		return mksCluster, err
+	}
+	opts.send(ctx, UpgradeEvent{Type: UpgradeEventPlanned, Steps: steps, Cluster: mksCluster})
+
+	// Steps are recomputed after each upgrade from the actual cluster version in case
+	// the API has upgraded the cluster to a different version than it was planned.
+	for len(steps) > 0 {
+		if err := ctx.Err(); err != nil {
+			return mksCluster, err
+		}
+
+		previousVersion := mksCluster.KubeVersion
+		mksCluster, err = upgradeStep(ctx, client, mksCluster, steps[0], opts)
+		if err != nil {
+			return mksCluster, err
+		}
+		if mksCluster.KubeVersion == previousVersion {
+			return mksCluster, fmt.Errorf("%w: %s", ErrUpgradeNotApplied, previousVersion)
+		}
+
+		steps, err = kubeversion.PlanUpgrade(mksCluster.KubeVersion, versions, targetVersion)
+		if err != nil {
+			return mksCluster, err
+		}
+	}
+	opts.send(ctx, UpgradeEvent{Type: UpgradeEventFinished, Cluster: mksCluster})
+
+	return mksCluster, nil
+}
+
+// upgradeStep runs a single upgrade step and waits until the cluster is active.
+func upgradeStep(ctx context.Context, client *v1.ServiceClient, mksCluster *GetView, step *kubeversion.UpgradeStep,
+	opts *UpgradeOpts,
+) (*GetView, error) {
+	taskType := task.TypeUpgradePatchVersion
+	upgrade := UpgradePatchVersion
+	if step.Type == kubeversion.UpgradeTypeMinor {
+		taskType, upgrade = task.TypeUpgradeMinorVersion, UpgradeMinorVersion
+	}
+
+	operation, err := task.NewOperation(ctx, client, mksCluster.ID, taskType, "")
+	if err != nil {
+		return mksCluster, err
+	}
+	operation.PollInterval = opts.PollInterval
+
+	_, responseResult, err := upgrade(ctx, client, mksCluster.ID)
+	if err != nil {
+		return mksCluster, err
+	}
+	operation.SetResponse(responseResult)
+	opts.send(ctx, UpgradeEvent{Type: UpgradeEventStepStarted, Step: step, Cluster: mksCluster})
+
+	upgradeTask, err := operation.Task(ctx)
+	if err != nil {
+		return mksCluster, err
+	}
+	opts.send(ctx, UpgradeEvent{Type: UpgradeEventTaskStarted, Step: step, TaskID: upgradeTask.ID, Cluster: mksCluster})
+
+	if _, err := operation.Wait(ctx); err != nil {
+		return mksCluster, err
+	}
+
+	activeCluster, err := WaitForActive(ctx, client, mksCluster.ID, opts.PollInterval)
+	if err != nil {
+		if activeCluster != nil {
+			mksCluster = activeCluster
+		}
+
+		return mksCluster, err
+	}
+	opts.send(ctx, UpgradeEvent{Type: UpgradeEventStepFinished, Step: step, TaskID: upgradeTask.ID, Cluster: activeCluster})
+
+	return activeCluster, nil
+}
+
+// send sends the event to the progress channel if it's set.
+func (opts *UpgradeOpts) send(ctx context.Context, event UpgradeEvent) {
+	if opts.Progress == nil {
+		return
+	}
+
+	select {
+	case opts.Progress <- event:
+	case <-ctx.Done():
+	}
+}
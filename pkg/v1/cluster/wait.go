@@ -0,0 +1,40 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	v1 "github.com/selectel/mks-go/pkg/v1"
+	"github.com/selectel/mks-go/pkg/v1/task"
+)
+
+// ErrClusterError is returned when a cluster has the error status.
+var ErrClusterError = errors.New("cluster has the error status")
+
+// WaitForActive waits until a cluster referenced by its id has the active status and returns it.
+// ErrClusterError is returned along with the cluster if the cluster has the error status.
+// task.DefaultPollInterval is used if the provided interval is not positive.
+func WaitForActive(ctx context.Context, client *v1.ServiceClient, clusterID string, interval time.Duration) (*GetView, error) {
+	if interval <= 0 {
+		interval = task.DefaultPollInterval
+	}
+
+	for {
+		mksCluster, _, err := Get(ctx, client, clusterID)
+		if err != nil {
+			return nil, err
+		}
+		if mksCluster.Status.IsStable() {
+			return mksCluster, nil
+		}
+		if mksCluster.Status.IsError() {
+			return mksCluster, fmt.Errorf("%w: %s", ErrClusterError, mksCluster.ID)
+		}
+
+		if err := v1.Sleep(ctx, interval); err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+// ErrUnknownFields is returned in the strict decoding mode when an API response
+// contains fields that are not modelled by the client.
+var ErrUnknownFields = errors.New("unknown fields in the API response")
+
+// strictDecoding represents the state of the strict decoding mode.
+var strictDecoding atomic.Bool
+
+// SetStrictDecoding enables or disables the strict decoding mode.
+// In the strict mode views fail to unmarshal if the API response contains unknown fields.
+// It's intended to be used in contract tests.
+func SetStrictDecoding(enabled bool) {
+	strictDecoding.Store(enabled)
+}
+
+// StrictDecoding returns true if the strict decoding mode is enabled.
+func StrictDecoding() bool {
+	return strictDecoding.Load()
+}
+
+// ExtractExtraFields returns JSON object fields that don't match json tags of the provided view.
+// Additional known field names can be provided for fields that are decoded separately.
+// Nil is returned if there are no extra fields. ErrUnknownFields is returned
+// if there are extra fields and the strict decoding mode is enabled.
+func ExtractExtraFields(b []byte, view interface{}, known ...string) (map[string]json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+
+	knownFields := jsonFieldNames(reflect.TypeOf(view))
+	for _, name := range known {
+		knownFields[name] = struct{}{}
+	}
+	for name := range fields {
+		if _, ok := knownFields[name]; ok {
+			delete(fields, name)
+		}
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	if StrictDecoding() {
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFields, strings.Join(names, ", "))
+	}
+
+	return fields, nil
+}
+
+// MarshalWithExtraFields marshals the provided view and adds extra fields to the resulting JSON object.
+// Fields of the view take precedence over extra fields with the same name.
+func MarshalWithExtraFields(view interface{}, extra map[string]json.RawMessage) ([]byte, error) {
+	b, err := json.Marshal(view)
+	if err != nil || len(extra) == 0 {
+		return b, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	for name, value := range extra {
+		if _, ok := fields[name]; !ok {
+			fields[name] = value
+		}
+	}
+
+	return json.Marshal(fields)
+}
+
+// jsonFieldNames returns JSON field names of the struct type including fields of embedded structs.
+func jsonFieldNames(t reflect.Type) map[string]struct{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	names := make(map[string]struct{})
+	if t.Kind() != reflect.Struct {
+		return names
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if field.Anonymous && name == "" {
+			for embeddedName := range jsonFieldNames(field.Type) {
+				names[embeddedName] = struct{}{}
+			}
+
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names[name] = struct{}{}
+	}
+
+	return names
+}
@@ -0,0 +1,46 @@
+package flavor
+
+import (
+	"github.com/selectel/mks-go/pkg/v1/nodegroup"
+)
+
+// NodeResources returns resources of a single node of the nodegroup. CPU and RAM are resolved
+// from the nodegroup flavor. The disk size is taken from the flavor for nodegroups with a local
+// volume and from the nodegroup volume otherwise.
+func (catalog *Catalog) NodeResources(ng *nodegroup.BaseView) (Resources, error) {
+	f, err := catalog.Get(ng.FlavorID)
+	if err != nil {
+		return Resources{}, err
+	}
+
+	resources := f.Resources()
+	if !ng.LocalVolume {
+		resources.DiskGB = ng.VolumeGB
+	}
+
+	return resources, nil
+}
+
+// NodegroupResources returns resources of all current nodes of the nodegroup.
+func (catalog *Catalog) NodegroupResources(ng *nodegroup.BaseView) (Resources, error) {
+	resources, err := catalog.NodeResources(ng)
+	if err != nil {
+		return Resources{}, err
+	}
+
+	return resources.Multiply(len(ng.Nodes)), nil
+}
+
+// Capacity returns total resources of the nodegroups returned by nodegroup.List.
+func (catalog *Catalog) Capacity(nodegroups []*nodegroup.ListView) (Resources, error) {
+	var total Resources
+	for _, ng := range nodegroups {
+		resources, err := catalog.NodegroupResources(&ng.BaseView)
+		if err != nil {
+			return Resources{}, err
+		}
+		total = total.Add(resources)
+	}
+
+	return total, nil
+}
@@ -0,0 +1,150 @@
+package flavor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// ErrFlavorNotFound is returned when a flavor is absent in the catalog.
+	ErrFlavorNotFound = errors.New("flavor not found")
+
+	// ErrNoMatchingFlavor is returned when no flavor of the catalog satisfies the requirements.
+	ErrNoMatchingFlavor = errors.New("no flavor matches the requirements")
+)
+
+// Catalog represents a set of flavors available in a region.
+type Catalog struct {
+	flavors []*Flavor
+	byID    map[string]*Flavor
+}
+
+// NewCatalog returns a catalog of the provided flavors.
+func NewCatalog(flavors []*Flavor) *Catalog {
+	catalog := &Catalog{
+		flavors: make([]*Flavor, 0, len(flavors)),
+		byID:    make(map[string]*Flavor, len(flavors)),
+	}
+	for _, f := range flavors {
+		catalog.flavors = append(catalog.flavors, f)
+		catalog.byID[f.ID] = f
+	}
+
+	return catalog
+}
+
+// Parse parses a catalog from a compute API flavors response or a static file in the YAML
+// or JSON format. Both formats contain a list of flavors under the "flavors" key.
+func Parse(data []byte) (*Catalog, error) {
+	// YAML is a superset of JSON so both formats are decoded into a generic
+	// structure first and then converted through JSON to reuse the field names.
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	jsonData, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Flavors []*Flavor `json:"flavors"`
+	}
+	if err := json.Unmarshal(jsonData, &result); err != nil {
+		return nil, err
+	}
+
+	return NewCatalog(result.Flavors), nil
+}
+
+// LoadFile reads and parses a catalog from the file.
+func LoadFile(path string) (*Catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(data)
+}
+
+// Flavors returns all flavors of the catalog.
+func (catalog *Catalog) Flavors() []*Flavor {
+	return append([]*Flavor(nil), catalog.flavors...)
+}
+
+// Get returns a flavor by its id.
+func (catalog *Catalog) Get(id string) (*Flavor, error) {
+	f, ok := catalog.byID[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrFlavorNotFound, id)
+	}
+
+	return f, nil
+}
+
+// Requirements represents minimal resources of a node.
+type Requirements struct {
+	// VCPUs represents the minimal number of virtual CPUs.
+	VCPUs int
+
+	// RAMMB represents the minimal amount of RAM in MB.
+	RAMMB int
+
+	// DiskGB represents the minimal size of the local disk in GB.
+	// It should be zero for nodes with network volumes.
+	DiskGB int
+
+	// Filter excludes flavors from matching if it's set and returns false.
+	Filter func(f *Flavor) bool
+}
+
+// Satisfies returns true if the flavor satisfies the requirements.
+func (req *Requirements) Satisfies(f *Flavor) bool {
+	if req.Filter != nil && !req.Filter(f) {
+		return false
+	}
+
+	return f.VCPUs >= req.VCPUs && f.RAMMB >= req.RAMMB && f.DiskGB >= req.DiskGB
+}
+
+// Match returns the smallest flavor that satisfies the requirements. Flavors are compared
+// by the number of CPUs, then by RAM and the disk size, so the flavor with the least
+// excess resources is returned. ErrNoMatchingFlavor is returned if there is no such flavor.
+func (catalog *Catalog) Match(req *Requirements) (*Flavor, error) {
+	var candidates []*Flavor
+	for _, f := range catalog.flavors {
+		if req.Satisfies(f) {
+			candidates = append(candidates, f)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("%w: %d vCPU, %d MB RAM, %d GB disk",
+			ErrNoMatchingFlavor, req.VCPUs, req.RAMMB, req.DiskGB)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return lessFlavor(candidates[i], candidates[j])
+	})
+
+	return candidates[0], nil
+}
+
+// lessFlavor compares flavors by CPUs, RAM, disk and id.
+func lessFlavor(a, b *Flavor) bool {
+	if a.VCPUs != b.VCPUs {
+		return a.VCPUs < b.VCPUs
+	}
+	if a.RAMMB != b.RAMMB {
+		return a.RAMMB < b.RAMMB
+	}
+	if a.DiskGB != b.DiskGB {
+		return a.DiskGB < b.DiskGB
+	}
+
+	return a.ID < b.ID
+}
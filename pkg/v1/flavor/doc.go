@@ -0,0 +1,40 @@
+/*
+Package flavor provides a catalog of compute flavors that can be used to choose
+a flavor for nodegroup nodes and to resolve nodegroup flavors into resources.
+
+The catalog is loaded from a static file or a compute API flavors response.
+
+Example of loading a catalog and choosing a flavor for a new nodegroup
+
+	catalog, err := flavor.LoadFile("flavors.yaml")
+	if err != nil {
+	  log.Fatal(err)
+	}
+	nodeFlavor, err := catalog.Match(&flavor.Requirements{
+	  VCPUs: 4,
+	  RAMMB: 8192,
+	})
+	if err != nil {
+	  log.Fatal(err)
+	}
+	createOpts := &nodegroup.CreateOpts{
+	  Count:            3,
+	  FlavorID:         nodeFlavor.ID,
+	  VolumeGB:         20,
+	  VolumeType:       "fast.ru-3a",
+	  AvailabilityZone: "ru-3a",
+	}
+
+Example of calculating total resources of cluster nodegroups
+
+	nodegroups, _, err := nodegroup.List(ctx, mksClient, clusterID)
+	if err != nil {
+	  log.Fatal(err)
+	}
+	capacity, err := catalog.Capacity(nodegroups)
+	if err != nil {
+	  log.Fatal(err)
+	}
+	fmt.Println(capacity)
+*/
+package flavor
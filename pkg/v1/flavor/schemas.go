@@ -0,0 +1,75 @@
+package flavor
+
+import "fmt"
+
+// Flavor represents a compute flavor that can be used by nodegroup nodes.
+// Field names follow the flavors response of the compute API.
+type Flavor struct {
+	// ID is the identifier of the flavor.
+	ID string `json:"id"`
+
+	// Name represents the name of the flavor.
+	Name string `json:"name"`
+
+	// VCPUs represents the number of virtual CPUs.
+	VCPUs int `json:"vcpus"`
+
+	// RAMMB represents the amount of RAM in MB.
+	RAMMB int `json:"ram"`
+
+	// DiskGB represents the size of the local disk in GB. It's zero for flavors
+	// that are used with network volumes only.
+	DiskGB int `json:"disk"`
+
+	// ExtraSpecs contains additional properties of the flavor.
+	ExtraSpecs map[string]string `json:"extra_specs,omitempty"`
+}
+
+// Resources returns resources of a single node with the flavor.
+func (f *Flavor) Resources() Resources {
+	return Resources{
+		VCPUs:  f.VCPUs,
+		RAMMB:  f.RAMMB,
+		DiskGB: f.DiskGB,
+	}
+}
+
+// String returns the flavor name with its resources.
+func (f *Flavor) String() string {
+	return fmt.Sprintf("%s (%s)", f.Name, f.Resources())
+}
+
+// Resources represents an amount of compute resources.
+type Resources struct {
+	// VCPUs represents the number of virtual CPUs.
+	VCPUs int `json:"vcpus"`
+
+	// RAMMB represents the amount of RAM in MB.
+	RAMMB int `json:"ram_mb"`
+
+	// DiskGB represents the disk size in GB.
+	DiskGB int `json:"disk_gb"`
+}
+
+// Add returns the sum of the resources.
+func (r Resources) Add(other Resources) Resources {
+	return Resources{
+		VCPUs:  r.VCPUs + other.VCPUs,
+		RAMMB:  r.RAMMB + other.RAMMB,
+		DiskGB: r.DiskGB + other.DiskGB,
+	}
+}
+
+// Multiply returns the resources multiplied by the provided number of nodes.
+func (r Resources) Multiply(n int) Resources {
+	return Resources{
+		VCPUs:  r.VCPUs * n,
+		RAMMB:  r.RAMMB * n,
+		DiskGB: r.DiskGB * n,
+	}
+}
+
+// String returns the resources in a human-readable form.
+func (r Resources) String() string {
+	return fmt.Sprintf("%d vCPU, %d MB RAM, %d GB disk", r.VCPUs, r.RAMMB, r.DiskGB)
+}
@@ -0,0 +1,135 @@
+package testing
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/selectel/mks-go/pkg/v1/flavor"
+	"github.com/selectel/mks-go/pkg/v1/nodegroup"
+)
+
+func TestParseComputeResponse(t *testing.T) {
+	catalog, err := flavor.Parse([]byte(testComputeFlavorsResponseRaw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(catalog.Flavors()) != 5 {
+		t.Fatalf("expected 5 flavors, but got %d", len(catalog.Flavors()))
+	}
+
+	gpuFlavor, err := catalog.Get("3011")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gpuFlavor.ExtraSpecs["pci_passthrough:alias"] != "GPU-TESLA-T4:1" {
+		t.Fatalf("expected extra specs of the GPU flavor, but got %v", gpuFlavor.ExtraSpecs)
+	}
+	if expected := "GL1.8-32768-gpu (8 vCPU, 32768 MB RAM, 0 GB disk)"; gpuFlavor.String() != expected {
+		t.Fatalf("expected %q, but got %q", expected, gpuFlavor.String())
+	}
+
+	if _, err := catalog.Get("unknown"); !errors.Is(err, flavor.ErrFlavorNotFound) {
+		t.Fatalf("expected ErrFlavorNotFound, but got %v", err)
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "flavors.yaml")
+	if err := os.WriteFile(path, []byte(testStaticFlavorsRaw), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	catalog, err := flavor.LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expectedStaticFlavors, catalog.Flavors()) {
+		t.Fatalf("expected %#v, but got %#v", expectedStaticFlavors, catalog.Flavors())
+	}
+}
+
+func TestMatch(t *testing.T) {
+	catalog, err := flavor.Parse([]byte(testComputeFlavorsResponseRaw))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := map[string]struct {
+		requirements *flavor.Requirements
+		expectedID   string
+	}{
+		"smallest": {
+			requirements: &flavor.Requirements{},
+			expectedID:   "1011",
+		},
+		"least excess CPU": {
+			requirements: &flavor.Requirements{VCPUs: 2, RAMMB: 4096},
+			expectedID:   "1013",
+		},
+		"network volume before local disk": {
+			requirements: &flavor.Requirements{VCPUs: 4, RAMMB: 8192},
+			expectedID:   "1015",
+		},
+		"local disk": {
+			requirements: &flavor.Requirements{VCPUs: 2, DiskGB: 50},
+			expectedID:   "1311",
+		},
+		"filter": {
+			requirements: &flavor.Requirements{
+				Filter: func(f *flavor.Flavor) bool { return len(f.ExtraSpecs) > 0 },
+			},
+			expectedID: "3011",
+		},
+	}
+
+	for name, testCase := range testCases {
+		actual, err := catalog.Match(testCase.requirements)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if actual.ID != testCase.expectedID {
+			t.Fatalf("%s: expected %s flavor, but got %s", name, testCase.expectedID, actual.ID)
+		}
+	}
+
+	_, err = catalog.Match(&flavor.Requirements{VCPUs: 64})
+	if !errors.Is(err, flavor.ErrNoMatchingFlavor) {
+		t.Fatalf("expected ErrNoMatchingFlavor, but got %v", err)
+	}
+}
+
+func TestCapacity(t *testing.T) {
+	catalog, err := flavor.Parse([]byte(testComputeFlavorsResponseRaw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var nodegroups []*nodegroup.ListView
+	if err := json.Unmarshal([]byte(testListNodegroupsResourcesRaw), &nodegroups); err != nil {
+		t.Fatal(err)
+	}
+
+	nodeResources, err := catalog.NodeResources(&nodegroups[0].BaseView)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := (flavor.Resources{VCPUs: 4, RAMMB: 8192, DiskGB: 20}); nodeResources != expected {
+		t.Fatalf("expected %v, but got %v", expected, nodeResources)
+	}
+
+	capacity, err := catalog.Capacity(nodegroups)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := (flavor.Resources{VCPUs: 16, RAMMB: 32768, DiskGB: 124}); capacity != expected {
+		t.Fatalf("expected %v, but got %v", expected, capacity)
+	}
+
+	nodegroups[1].FlavorID = "unknown"
+	if _, err := catalog.Capacity(nodegroups); !errors.Is(err, flavor.ErrFlavorNotFound) {
+		t.Fatalf("expected ErrFlavorNotFound, but got %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+package testing
+
+import "github.com/selectel/mks-go/pkg/v1/flavor"
+
+// testComputeFlavorsResponseRaw represents a flavors response of the compute API.
+const testComputeFlavorsResponseRaw = `
+{
+    "flavors": [
+        {
+            "id": "1011",
+            "name": "SL1.1-1024",
+            "vcpus": 1,
+            "ram": 1024,
+            "disk": 0,
+            "OS-FLV-EXT-DATA:ephemeral": 0,
+            "os-flavor-access:is_public": true,
+            "links": []
+        },
+        {
+            "id": "1015",
+            "name": "SL1.4-8192",
+            "vcpus": 4,
+            "ram": 8192,
+            "disk": 0,
+            "links": []
+        },
+        {
+            "id": "1013",
+            "name": "SL1.2-8192",
+            "vcpus": 2,
+            "ram": 8192,
+            "disk": 0,
+            "links": []
+        },
+        {
+            "id": "1311",
+            "name": "SL1.4-8192-64",
+            "vcpus": 4,
+            "ram": 8192,
+            "disk": 64,
+            "links": []
+        },
+        {
+            "id": "3011",
+            "name": "GL1.8-32768-gpu",
+            "vcpus": 8,
+            "ram": 32768,
+            "disk": 0,
+            "extra_specs": {
+                "pci_passthrough:alias": "GPU-TESLA-T4:1"
+            },
+            "links": []
+        }
+    ]
+}
+`
+
+// testStaticFlavorsRaw represents a static flavors file.
+const testStaticFlavorsRaw = `
+flavors:
+  - id: "1011"
+    name: SL1.1-1024
+    vcpus: 1
+    ram: 1024
+    disk: 0
+  - id: "1311"
+    name: SL1.4-8192-64
+    vcpus: 4
+    ram: 8192
+    disk: 64
+`
+
+var expectedStaticFlavors = []*flavor.Flavor{
+	{
+		ID:    "1011",
+		Name:  "SL1.1-1024",
+		VCPUs: 1,
+		RAMMB: 1024,
+	},
+	{
+		ID:     "1311",
+		Name:   "SL1.4-8192-64",
+		VCPUs:  4,
+		RAMMB:  8192,
+		DiskGB: 64,
+	},
+}
+
+// testListNodegroupsResourcesRaw represents nodegroups of different flavors and volumes.
+const testListNodegroupsResourcesRaw = `
+[
+    {
+        "id": "nodegroup-network-volume",
+        "flavor_id": "1015",
+        "volume_gb": 20,
+        "local_volume": false,
+        "status": "ACTIVE",
+        "nodes": [{"id": "node-1"}, {"id": "node-2"}, {"id": "node-3"}]
+    },
+    {
+        "id": "nodegroup-local-volume",
+        "flavor_id": "1311",
+        "local_volume": true,
+        "status": "ACTIVE",
+        "nodes": [{"id": "node-4"}]
+    }
+]
+`
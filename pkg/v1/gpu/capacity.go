@@ -0,0 +1,52 @@
+package gpu
+
+import (
+	"github.com/selectel/mks-go/pkg/v1/nodegroup"
+)
+
+// Capacity represents GPUs of cluster nodegroups.
+type Capacity struct {
+	// Total represents the total number of GPUs.
+	Total int
+
+	// ByModel contains the number of GPUs by their model.
+	ByModel map[string]int
+
+	// ByNodegroup contains the number of GPUs by the nodegroup id.
+	// Nodegroups without GPUs are omitted.
+	ByNodegroup map[string]int
+}
+
+// ClusterCapacity returns GPUs of the nodegroups returned by nodegroup.List.
+// Nodegroups without a flavor id have no GPUs.
+func ClusterCapacity(catalog FlavorCatalog, nodegroups []*nodegroup.ListView) (*Capacity, error) {
+	capacity := &Capacity{
+		ByModel:     make(map[string]int),
+		ByNodegroup: make(map[string]int),
+	}
+	for _, ng := range nodegroups {
+		if ng.FlavorID == "" {
+			continue
+		}
+		f, err := catalog.Get(ng.FlavorID)
+		if err != nil {
+			return nil, err
+		}
+		devices, err := Devices(f)
+		if err != nil {
+			return nil, err
+		}
+
+		for model, count := range devices {
+			gpus := count * len(ng.Nodes)
+			if gpus == 0 {
+				continue
+			}
+			capacity.Total += gpus
+			capacity.ByModel[model] += gpus
+			capacity.ByNodegroup[ng.ID] += gpus
+		}
+	}
+
+	return capacity, nil
+}
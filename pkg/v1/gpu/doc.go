@@ -0,0 +1,40 @@
+/*
+Package gpu provides helpers to create GPU nodegroups and to report GPUs of a cluster.
+
+GPUs of a flavor are read from its "pci_passthrough:alias" extra spec, so any catalog
+that returns flavors with extra specs can be used, for example *flavor.Catalog.
+
+Example of creating a GPU nodegroup
+
+	catalog, err := flavor.LoadFile("flavors.yaml")
+	if err != nil {
+	  log.Fatal(err)
+	}
+	createOpts := gpu.CreateOpts(&nodegroup.CreateOpts{
+	  Count:            2,
+	  FlavorID:         "3011",
+	  VolumeGB:         50,
+	  VolumeType:       "fast.ru-3a",
+	  AvailabilityZone: "ru-3a",
+	})
+	if err := gpu.Validate(catalog, createOpts); err != nil {
+	  log.Fatal(err)
+	}
+	_, err = nodegroup.Create(ctx, mksClient, clusterID, createOpts)
+	if err != nil {
+	  log.Fatal(err)
+	}
+
+Example of reporting GPUs of a cluster
+
+	nodegroups, _, err := nodegroup.List(ctx, mksClient, clusterID)
+	if err != nil {
+	  log.Fatal(err)
+	}
+	capacity, err := gpu.ClusterCapacity(catalog, nodegroups)
+	if err != nil {
+	  log.Fatal(err)
+	}
+	fmt.Printf("%d GPUs: %v\n", capacity.Total, capacity.ByModel)
+*/
+package gpu
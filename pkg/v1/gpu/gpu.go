@@ -0,0 +1,130 @@
+package gpu
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/selectel/mks-go/pkg/v1/flavor"
+	"github.com/selectel/mks-go/pkg/v1/nodegroup"
+)
+
+const (
+	// TaintKey represents the key of the standard taint of GPU nodes.
+	TaintKey = "nvidia.com/gpu"
+
+	// TaintValue represents the value of the standard taint of GPU nodes.
+	TaintValue = "present"
+
+	// LabelKey represents the label that marks GPU nodes.
+	LabelKey = "nvidia.com/gpu.present"
+
+	// PCIPassthroughSpec represents the flavor extra spec that lists passed through devices
+	// in the "alias:count[,alias:count]" form.
+	PCIPassthroughSpec = "pci_passthrough:alias"
+)
+
+var (
+	// ErrNotGPUFlavor is returned when the NVIDIA device plugin is requested for a flavor without GPUs.
+	ErrNotGPUFlavor = errors.New("flavor has no GPUs")
+
+	// ErrInvalidPCIPassthroughSpec is returned when the PCI passthrough extra spec can't be parsed.
+	ErrInvalidPCIPassthroughSpec = errors.New("invalid PCI passthrough extra spec")
+)
+
+// FlavorCatalog resolves flavors by their ids. It's implemented by *flavor.Catalog.
+type FlavorCatalog interface {
+	Get(id string) (*flavor.Flavor, error)
+}
+
+// Devices returns the number of GPUs of the flavor by their model.
+func Devices(f *flavor.Flavor) (map[string]int, error) {
+	devices := make(map[string]int)
+	spec := f.ExtraSpecs[PCIPassthroughSpec]
+	if spec == "" {
+		return devices, nil
+	}
+
+	for _, device := range strings.Split(spec, ",") {
+		alias, rawCount, ok := strings.Cut(strings.TrimSpace(device), ":")
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidPCIPassthroughSpec, spec)
+		}
+		count, err := strconv.Atoi(rawCount)
+		if err != nil || count < 0 {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidPCIPassthroughSpec, spec)
+		}
+		devices[alias] += count
+	}
+
+	return devices, nil
+}
+
+// Count returns the total number of GPUs of the flavor.
+func Count(f *flavor.Flavor) (int, error) {
+	devices, err := Devices(f)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, n := range devices {
+		count += n
+	}
+
+	return count, nil
+}
+
+// CreateOpts returns a copy of the nodegroup Create request options prepared for GPU nodes.
+// The NVIDIA device plugin installation is requested and the standard GPU taint
+// and label are added.
+func CreateOpts(template *nodegroup.CreateOpts) *nodegroup.CreateOpts {
+	opts := *template
+	installNvidiaDevicePlugin := true
+	opts.InstallNvidiaDevicePlugin = &installNvidiaDevicePlugin
+
+	opts.Labels = make(map[string]string, len(template.Labels)+1)
+	for k, v := range template.Labels {
+		opts.Labels[k] = v
+	}
+	opts.Labels[LabelKey] = "true"
+
+	patch := &nodegroup.TaintsPatch{
+		Add: []nodegroup.Taint{
+			{
+				Key:    TaintKey,
+				Value:  TaintValue,
+				Effect: nodegroup.NoScheduleEffect,
+			},
+		},
+	}
+	opts.Taints = patch.Apply(template.Taints)
+
+	return &opts
+}
+
+// Validate checks that the NVIDIA device plugin is requested only for a GPU flavor.
+// ErrNotGPUFlavor is returned if the flavor has no GPUs or the flavor id isn't set.
+func Validate(catalog FlavorCatalog, opts *nodegroup.CreateOpts) error {
+	if opts.InstallNvidiaDevicePlugin == nil || !*opts.InstallNvidiaDevicePlugin {
+		return nil
+	}
+	if opts.FlavorID == "" {
+		return fmt.Errorf("%w: flavor id isn't set", ErrNotGPUFlavor)
+	}
+
+	f, err := catalog.Get(opts.FlavorID)
+	if err != nil {
+		return err
+	}
+	count, err := Count(f)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("%w: %s", ErrNotGPUFlavor, f.Name)
+	}
+
+	return nil
+}
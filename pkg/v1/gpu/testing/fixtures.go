@@ -0,0 +1,59 @@
+package testing
+
+import "github.com/selectel/mks-go/pkg/v1/flavor"
+
+// testFlavors represents flavors of the test catalog.
+var testFlavors = []*flavor.Flavor{
+	{
+		ID:    "1015",
+		Name:  "SL1.4-8192",
+		VCPUs: 4,
+		RAMMB: 8192,
+	},
+	{
+		ID:         "3011",
+		Name:       "GL1.8-32768-T4",
+		VCPUs:      8,
+		RAMMB:      32768,
+		ExtraSpecs: map[string]string{"pci_passthrough:alias": "GPU-TESLA-T4:1"},
+	},
+	{
+		ID:         "3021",
+		Name:       "GL2.24-131072-A100",
+		VCPUs:      24,
+		RAMMB:      131072,
+		ExtraSpecs: map[string]string{"pci_passthrough:alias": "GPU-A100:2, GPU-TESLA-T4:1"},
+	},
+	{
+		ID:         "3099",
+		Name:       "GL-broken",
+		ExtraSpecs: map[string]string{"pci_passthrough:alias": "GPU-A100"},
+	},
+}
+
+// testListGPUNodegroupsRaw represents nodegroups of GPU and CPU flavors.
+const testListGPUNodegroupsRaw = `
+[
+    {
+        "id": "nodegroup-cpu",
+        "flavor_id": "1015",
+        "nodes": [{"id": "node-1"}, {"id": "node-2"}]
+    },
+    {
+        "id": "nodegroup-t4",
+        "flavor_id": "3011",
+        "install_nvidia_device_plugin": true,
+        "nodes": [{"id": "node-3"}, {"id": "node-4"}, {"id": "node-5"}]
+    },
+    {
+        "id": "nodegroup-a100",
+        "flavor_id": "3021",
+        "install_nvidia_device_plugin": true,
+        "nodes": [{"id": "node-6"}]
+    },
+    {
+        "id": "nodegroup-custom",
+        "nodes": [{"id": "node-7"}]
+    }
+]
+`
@@ -0,0 +1,119 @@
+package testing
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/selectel/mks-go/pkg/v1/flavor"
+	"github.com/selectel/mks-go/pkg/v1/gpu"
+	"github.com/selectel/mks-go/pkg/v1/nodegroup"
+)
+
+func TestDevices(t *testing.T) {
+	catalog := flavor.NewCatalog(testFlavors)
+
+	testCases := map[string]map[string]int{
+		"1015": {},
+		"3011": {"GPU-TESLA-T4": 1},
+		"3021": {"GPU-A100": 2, "GPU-TESLA-T4": 1},
+	}
+	for flavorID, expected := range testCases {
+		f, err := catalog.Get(flavorID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual, err := gpu.Devices(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(expected, actual) {
+			t.Fatalf("expected %v devices of %s flavor, but got %v", expected, flavorID, actual)
+		}
+	}
+
+	broken, err := catalog.Get("3099")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := gpu.Count(broken); !errors.Is(err, gpu.ErrInvalidPCIPassthroughSpec) {
+		t.Fatalf("expected ErrInvalidPCIPassthroughSpec, but got %v", err)
+	}
+}
+
+func TestCreateOpts(t *testing.T) {
+	template := &nodegroup.CreateOpts{
+		Count:    2,
+		FlavorID: "3011",
+		Labels:   map[string]string{"team": "ml"},
+		Taints: []nodegroup.Taint{
+			{Key: "nvidia.com/gpu", Value: "old", Effect: nodegroup.NoScheduleEffect},
+			{Key: "dedicated", Value: "ml", Effect: nodegroup.NoExecuteEffect},
+		},
+	}
+
+	opts := gpu.CreateOpts(template)
+	installNvidiaDevicePlugin := true
+	expected := &nodegroup.CreateOpts{
+		Count:                     2,
+		FlavorID:                  "3011",
+		Labels:                    map[string]string{"team": "ml", "nvidia.com/gpu.present": "true"},
+		InstallNvidiaDevicePlugin: &installNvidiaDevicePlugin,
+		Taints: []nodegroup.Taint{
+			{Key: "nvidia.com/gpu", Value: "present", Effect: nodegroup.NoScheduleEffect},
+			{Key: "dedicated", Value: "ml", Effect: nodegroup.NoExecuteEffect},
+		},
+	}
+	if !reflect.DeepEqual(expected, opts) {
+		t.Fatalf("expected %#v, but got %#v", expected, opts)
+	}
+	if template.InstallNvidiaDevicePlugin != nil || len(template.Labels) != 1 || template.Taints[0].Value != "old" {
+		t.Fatalf("expected the template not to be changed, but got %#v", template)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	catalog := flavor.NewCatalog(testFlavors)
+
+	if err := gpu.Validate(catalog, gpu.CreateOpts(&nodegroup.CreateOpts{FlavorID: "3011"})); err != nil {
+		t.Fatal(err)
+	}
+	if err := gpu.Validate(catalog, &nodegroup.CreateOpts{FlavorID: "1015"}); err != nil {
+		t.Fatal(err)
+	}
+
+	err := gpu.Validate(catalog, gpu.CreateOpts(&nodegroup.CreateOpts{FlavorID: "1015"}))
+	if !errors.Is(err, gpu.ErrNotGPUFlavor) {
+		t.Fatalf("expected ErrNotGPUFlavor, but got %v", err)
+	}
+	err = gpu.Validate(catalog, gpu.CreateOpts(&nodegroup.CreateOpts{CPUs: 4, RAMMB: 8192}))
+	if !errors.Is(err, gpu.ErrNotGPUFlavor) {
+		t.Fatalf("expected ErrNotGPUFlavor, but got %v", err)
+	}
+	err = gpu.Validate(catalog, gpu.CreateOpts(&nodegroup.CreateOpts{FlavorID: "unknown"}))
+	if !errors.Is(err, flavor.ErrFlavorNotFound) {
+		t.Fatalf("expected ErrFlavorNotFound, but got %v", err)
+	}
+}
+
+func TestClusterCapacity(t *testing.T) {
+	var nodegroups []*nodegroup.ListView
+	if err := json.Unmarshal([]byte(testListGPUNodegroupsRaw), &nodegroups); err != nil {
+		t.Fatal(err)
+	}
+
+	capacity, err := gpu.ClusterCapacity(flavor.NewCatalog(testFlavors), nodegroups)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &gpu.Capacity{
+		Total:       6,
+		ByModel:     map[string]int{"GPU-TESLA-T4": 4, "GPU-A100": 2},
+		ByNodegroup: map[string]int{"nodegroup-t4": 3, "nodegroup-a100": 3},
+	}
+	if !reflect.DeepEqual(expected, capacity) {
+		t.Fatalf("expected %#v, but got %#v", expected, capacity)
+	}
+}
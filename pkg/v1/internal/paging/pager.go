@@ -0,0 +1,247 @@
+// Package paging provides iteration over paged list endpoints of the MKS V1 API
+// for the cluster, nodegroup and task packages.
+package paging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	v1 "github.com/selectel/mks-go/pkg/v1"
+)
+
+// ErrListKeyNotFound is returned when a list response body doesn't contain the expected key.
+var ErrListKeyNotFound = errors.New("list key isn't found in the response body")
+
+// ListDecoder decodes elements of a JSON array placed under a key of a JSON object
+// one by one without reading the whole response body into memory.
+type ListDecoder struct {
+	decoder *json.Decoder
+	body    io.Closer
+	empty   bool
+}
+
+// NewListDecoder reads the response body until the beginning of the array under the provided key.
+// Other keys of the object are skipped. The body is closed if an error is returned.
+func NewListDecoder(body io.ReadCloser, key string) (*ListDecoder, error) {
+	decoder := json.NewDecoder(body)
+	if err := expectDelim(decoder, '{'); err != nil {
+		body.Close()
+
+		return nil, err
+	}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			body.Close()
+
+			return nil, err
+		}
+		if name, _ := token.(string); name != key {
+			var skipped json.RawMessage
+			if err := decoder.Decode(&skipped); err != nil {
+				body.Close()
+
+				return nil, err
+			}
+
+			continue
+		}
+
+		return newArrayDecoder(decoder, body)
+	}
+	body.Close()
+
+	return nil, fmt.Errorf("%w: %s", ErrListKeyNotFound, key)
+}
+
+// newArrayDecoder reads the beginning of the array, null is treated as an empty array.
+func newArrayDecoder(decoder *json.Decoder, body io.ReadCloser) (*ListDecoder, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		body.Close()
+
+		return nil, err
+	}
+	if token == nil {
+		return &ListDecoder{decoder: decoder, body: body, empty: true}, nil
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		body.Close()
+
+		return nil, fmt.Errorf("expected JSON array but got %v", token)
+	}
+
+	return &ListDecoder{decoder: decoder, body: body}, nil
+}
+
+// expectDelim reads the next token and checks that it's the provided delimiter.
+func expectDelim(decoder *json.Decoder, expected json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != expected {
+		return fmt.Errorf("expected %v in JSON but got %v", expected, token)
+	}
+
+	return nil
+}
+
+// More returns true if there is another element in the array.
+func (d *ListDecoder) More() bool {
+	return !d.empty && d.decoder.More()
+}
+
+// Decode decodes the next element of the array.
+func (d *ListDecoder) Decode(v interface{}) error {
+	return d.decoder.Decode(v)
+}
+
+// Close closes the response body.
+func (d *ListDecoder) Close() error {
+	return d.body.Close()
+}
+
+// Pager iterates over elements of a list endpoint requesting pages with limit and marker
+// query parameters. Pages are decoded with ListDecoder.
+//
+// Paging stops when a page has less elements than the limit. If the server ignores
+// the limit, the whole list is returned in a single page. If the server ignores
+// the marker, a repeated page is detected by its first element and paging stops.
+type Pager[T any] struct {
+	client *v1.ServiceClient
+	url    string
+	key    string
+	limit  int
+	id     func(T) string
+
+	decoder     *ListDecoder
+	current     T
+	marker      string
+	pageFirstID string
+	pageCount   int
+	done        bool
+	err         error
+}
+
+// NewPager returns a pager over the list endpoint with elements placed under the provided key
+// of the response body. The id function returns the marker of an element.
+// All elements are requested in a single page if the limit isn't positive.
+func NewPager[T any](client *v1.ServiceClient, url, key string, limit int, id func(T) string) *Pager[T] {
+	return &Pager[T]{
+		client: client,
+		url:    url,
+		key:    key,
+		limit:  limit,
+		id:     id,
+	}
+}
+
+// Next advances the pager to the next element and returns false when there are no more
+// elements or an error has occurred. The context is used for page requests,
+// the page body is read with the context of the request that has retrieved it.
+func (p *Pager[T]) Next(ctx context.Context) bool {
+	for p.err == nil && !p.done {
+		if p.decoder == nil {
+			p.err = p.fetch(ctx)
+
+			continue
+		}
+		if p.decoder.More() {
+			return p.decodeNext()
+		}
+
+		p.err = p.decoder.Close()
+		p.decoder = nil
+		p.done = p.limit <= 0 || p.pageCount != p.limit
+	}
+
+	return false
+}
+
+// Current returns the element that the pager is pointing to.
+func (p *Pager[T]) Current() T {
+	return p.current
+}
+
+// Err returns the error that has stopped the pager.
+func (p *Pager[T]) Err() error {
+	return p.err
+}
+
+// Close releases the body of the current page. It's needed only if iteration is stopped early.
+func (p *Pager[T]) Close() error {
+	p.done = true
+	if p.decoder == nil {
+		return nil
+	}
+	err := p.decoder.Close()
+	p.decoder = nil
+
+	return err
+}
+
+// decodeNext decodes the next element of the current page.
+func (p *Pager[T]) decodeNext() bool {
+	var element T
+	if err := p.decoder.Decode(&element); err != nil {
+		p.err = err
+
+		return false
+	}
+
+	id := p.id(element)
+	if p.pageCount == 0 {
+		if p.marker != "" && id == p.pageFirstID {
+			// The server has ignored the marker and returned the same page.
+			p.done = true
+			p.err = p.decoder.Close()
+			p.decoder = nil
+
+			return false
+		}
+		p.pageFirstID = id
+	}
+	p.pageCount++
+	p.marker = id
+	p.current = element
+
+	return true
+}
+
+// fetch requests the next page.
+func (p *Pager[T]) fetch(ctx context.Context) error {
+	pageURL := p.url
+	if p.limit > 0 {
+		query := url.Values{}
+		query.Set("limit", strconv.Itoa(p.limit))
+		if p.marker != "" {
+			query.Set("marker", p.marker)
+		}
+		pageURL += "?" + query.Encode()
+	}
+
+	responseResult, err := p.client.DoRequest(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return err
+	}
+	if responseResult.Err != nil {
+		return responseResult.Err
+	}
+
+	decoder, err := NewListDecoder(responseResult.Body, p.key)
+	if err != nil {
+		return err
+	}
+	p.decoder = decoder
+	p.pageCount = 0
+
+	return nil
+}
@@ -0,0 +1,254 @@
+package paging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/selectel/mks-go/pkg/testutils"
+	v1 "github.com/selectel/mks-go/pkg/v1"
+)
+
+type testPagerItem struct {
+	ID string `json:"id"`
+}
+
+func testPagerItemID(item *testPagerItem) string {
+	return item.ID
+}
+
+// handleTestPagerItems registers a handler that returns items with the limit and marker support.
+func handleTestPagerItems(mux *http.ServeMux, total int, supportLimit, supportMarker bool, requests *[]string) {
+	mux.HandleFunc("/v1/items", func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.URL.RawQuery)
+
+		start, end := 0, total
+		if limit, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && supportLimit {
+			if marker := r.URL.Query().Get("marker"); marker != "" && supportMarker {
+				start, _ = strconv.Atoi(strings.TrimPrefix(marker, "item-"))
+				start++
+			}
+			end = start + limit
+			if end > total {
+				end = total
+			}
+		}
+
+		items := make([]string, 0, end-start)
+		for i := start; i < end; i++ {
+			items = append(items, fmt.Sprintf(`{"id": "item-%d"}`, i))
+		}
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"meta": {"total": %d}, "items": [%s]}`, total, strings.Join(items, ","))
+	})
+}
+
+// collectTestPagerItems returns ids of all items of the pager.
+func collectTestPagerItems(t *testing.T, pager *Pager[*testPagerItem]) []string {
+	t.Helper()
+
+	var ids []string
+	for pager.Next(context.Background()) {
+		ids = append(ids, pager.Current().ID)
+	}
+	if err := pager.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	return ids
+}
+
+func expectedTestPagerItems(total int) []string {
+	ids := make([]string, 0, total)
+	for i := 0; i < total; i++ {
+		ids = append(ids, fmt.Sprintf("item-%d", i))
+	}
+
+	return ids
+}
+
+func newTestPagerClient(testEnv *testutils.TestEnv) *v1.ServiceClient {
+	return &v1.ServiceClient{
+		HTTPClient: &http.Client{},
+		TokenID:    testutils.TokenID,
+		Endpoint:   testEnv.Server.URL + "/v1",
+		UserAgent:  testutils.UserAgent,
+	}
+}
+
+func TestListDecoder(t *testing.T) {
+	body := io.NopCloser(strings.NewReader(`{"meta": {"a": [1, 2]}, "items": [{"id": "a"}, {"id": "b"}]}`))
+	decoder, err := NewListDecoder(body, "items")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer decoder.Close()
+
+	var ids []string
+	for decoder.More() {
+		var item testPagerItem
+		if err := decoder.Decode(&item); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, item.ID)
+	}
+	if !reflect.DeepEqual([]string{"a", "b"}, ids) {
+		t.Fatalf("expected [a b], but got %v", ids)
+	}
+}
+
+func TestListDecoderNull(t *testing.T) {
+	decoder, err := NewListDecoder(io.NopCloser(strings.NewReader(`{"items": null}`)), "items")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoder.More() {
+		t.Fatal("expected empty list")
+	}
+}
+
+func TestListDecoderInvalid(t *testing.T) {
+	_, err := NewListDecoder(io.NopCloser(strings.NewReader(`{"other": []}`)), "items")
+	if !errors.Is(err, ErrListKeyNotFound) {
+		t.Fatalf("expected ErrListKeyNotFound, but got %v", err)
+	}
+
+	_, err = NewListDecoder(io.NopCloser(strings.NewReader(`{"items": {}}`)), "items")
+	if err == nil {
+		t.Fatal("expected error for an object instead of an array")
+	}
+}
+
+func TestPager(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	var requests []string
+	handleTestPagerItems(testEnv.Mux, 7, true, true, &requests)
+
+	client := newTestPagerClient(testEnv)
+	pager := NewPager(client, client.Endpoint+"/items", "items", 3, testPagerItemID)
+
+	actual := collectTestPagerItems(t, pager)
+	if !reflect.DeepEqual(expectedTestPagerItems(7), actual) {
+		t.Fatalf("expected %v, but got %v", expectedTestPagerItems(7), actual)
+	}
+	expectedRequests := []string{"limit=3", "limit=3&marker=item-2", "limit=3&marker=item-5"}
+	if !reflect.DeepEqual(expectedRequests, requests) {
+		t.Fatalf("expected %v requests, but got %v", expectedRequests, requests)
+	}
+}
+
+func TestPagerWithoutLimit(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	var requests []string
+	handleTestPagerItems(testEnv.Mux, 5, true, true, &requests)
+
+	client := newTestPagerClient(testEnv)
+	pager := NewPager(client, client.Endpoint+"/items", "items", 0, testPagerItemID)
+
+	actual := collectTestPagerItems(t, pager)
+	if !reflect.DeepEqual(expectedTestPagerItems(5), actual) {
+		t.Fatalf("expected %v, but got %v", expectedTestPagerItems(5), actual)
+	}
+	if len(requests) != 1 || requests[0] != "" {
+		t.Fatalf("expected a single request without query, but got %v", requests)
+	}
+}
+
+func TestPagerServerIgnoresPaging(t *testing.T) {
+	for _, supportLimit := range []bool{false, true} {
+		testEnv := testutils.SetupTestEnv()
+		var requests []string
+		// The server returns the first page for every request if it only supports the limit.
+		handleTestPagerItems(testEnv.Mux, 6, supportLimit, false, &requests)
+
+		client := newTestPagerClient(testEnv)
+		pager := NewPager(client, client.Endpoint+"/items", "items", 3, testPagerItemID)
+
+		actual := collectTestPagerItems(t, pager)
+		testEnv.TearDownTestEnv()
+
+		expected := expectedTestPagerItems(6)
+		if supportLimit {
+			expected = expectedTestPagerItems(3)
+		}
+		if !reflect.DeepEqual(expected, actual) {
+			t.Fatalf("expected %v, but got %v", expected, actual)
+		}
+		if len(requests) > 2 {
+			t.Fatalf("expected no more than 2 requests, but got %v", requests)
+		}
+	}
+}
+
+// closeCountingTransport counts response bodies that haven't been closed.
+type closeCountingTransport struct {
+	open int
+}
+
+type closeCountingBody struct {
+	io.ReadCloser
+	transport *closeCountingTransport
+}
+
+func (body *closeCountingBody) Close() error {
+	body.transport.open--
+
+	return body.ReadCloser.Close()
+}
+
+func (transport *closeCountingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	response, err := http.DefaultTransport.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+	transport.open++
+	response.Body = &closeCountingBody{ReadCloser: response.Body, transport: transport}
+
+	return response, nil
+}
+
+func TestPagerClosesRepeatedPage(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	var requests []string
+	handleTestPagerItems(testEnv.Mux, 6, true, false, &requests)
+
+	transport := &closeCountingTransport{}
+	client := newTestPagerClient(testEnv)
+	client.HTTPClient.Transport = transport
+	pager := NewPager(client, client.Endpoint+"/items", "items", 3, testPagerItemID)
+
+	actual := collectTestPagerItems(t, pager)
+	if !reflect.DeepEqual(expectedTestPagerItems(3), actual) {
+		t.Fatalf("expected %v, but got %v", expectedTestPagerItems(3), actual)
+	}
+	if transport.open != 0 {
+		t.Fatalf("expected all response bodies to be closed, but %d are open", transport.open)
+	}
+}
+
+func TestPagerHTTPError(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	testEnv.Mux.HandleFunc("/v1/items", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	client := newTestPagerClient(testEnv)
+	pager := NewPager(client, client.Endpoint+"/items", "items", 3, testPagerItemID)
+
+	if pager.Next(context.Background()) {
+		t.Fatal("expected no items")
+	}
+	if pager.Err() == nil {
+		t.Fatal("expected error from the pager")
+	}
+}
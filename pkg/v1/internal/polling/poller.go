@@ -0,0 +1,79 @@
+// Package polling provides the poll loop and event handler plumbing shared by
+// the watcher and preemptible packages.
+package polling
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	v1 "github.com/selectel/mks-go/pkg/v1"
+)
+
+// Handler handles events of pollers such as the fleet watcher and the preemptible reconciler.
+type Handler[E any] interface {
+	Handle(ctx context.Context, event E) error
+}
+
+// HandlerFunc is an adapter to use ordinary functions as handlers.
+type HandlerFunc[E any] func(ctx context.Context, event E) error
+
+// Handle calls the function.
+func (f HandlerFunc[E]) Handle(ctx context.Context, event E) error {
+	return f(ctx, event)
+}
+
+// Poller runs polls periodically and passes their events to the handlers.
+type Poller[E any] struct {
+	// Interval represents the interval between polls.
+	Interval time.Duration
+
+	// Handlers receive every event in order.
+	Handlers []Handler[E]
+
+	// ErrorHandler receives errors of polls and handlers. Errors are ignored if it's not set.
+	ErrorHandler func(err error)
+}
+
+// Run calls the poll function until the context is done and returns the context error.
+// Errors of the poll function are reported unless the context is done.
+func (p *Poller[E]) Run(ctx context.Context, poll func(ctx context.Context) error) error {
+	for {
+		if err := poll(ctx); err != nil && ctx.Err() == nil {
+			p.ReportError(err)
+		}
+
+		if err := v1.Sleep(ctx, p.Interval); err != nil {
+			return err
+		}
+	}
+}
+
+// Dispatch passes every event to the handlers in order and reports their errors.
+func (p *Poller[E]) Dispatch(ctx context.Context, events []E) {
+	for _, event := range events {
+		for _, handler := range p.Handlers {
+			if err := handler.Handle(ctx, event); err != nil {
+				p.ReportError(err)
+			}
+		}
+	}
+}
+
+// ReportError passes the error to the error handler if it's set.
+func (p *Poller[E]) ReportError(err error) {
+	if p.ErrorHandler != nil {
+		p.ErrorHandler(err)
+	}
+}
+
+// SortedKeys returns sorted keys of the map, pollers use it to emit events in a stable order.
+func SortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
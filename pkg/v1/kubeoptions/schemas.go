@@ -1,5 +1,7 @@
 package kubeoptions
 
+import "strings"
+
 // View represents list of feature-gates/admission-controllers by kubernetes version.
 type View struct {
 	// KubeVersion represents the Kubernetes minor version in format: "X.Y".
@@ -8,3 +10,20 @@ type View struct {
 	// Names represents list of feature-gate names.
 	Names []string `json:"Names"`
 }
+
+// FindByKubeVersion returns feature-gates/admission-controllers list of the Kubernetes version.
+// Version can be provided in "X.Y" or "X.Y.Z" format.
+// Nil is returned if there is no list for the version.
+func FindByKubeVersion(views []*View, kubeVersion string) *View {
+	minor := kubeVersion
+	if parts := strings.Split(kubeVersion, "."); len(parts) > 2 {
+		minor = strings.Join(parts[:2], ".")
+	}
+	for _, view := range views {
+		if view.KubeVersion == minor {
+			return view
+		}
+	}
+
+	return nil
+}
@@ -220,3 +220,19 @@ func TestListAdmissionControllersUnmarshallError(t *testing.T) {
 		t.Fatal("expected error from the List method")
 	}
 }
+
+func TestFindByKubeVersion(t *testing.T) {
+	actual := kubeoptions.FindByKubeVersion(expectedFeatureGates, "1.16.3")
+	if !reflect.DeepEqual(expectedFeatureGates[1], actual) {
+		t.Fatalf("expected %#v, but got %#v", expectedFeatureGates[1], actual)
+	}
+
+	actual = kubeoptions.FindByKubeVersion(expectedFeatureGates, "1.17")
+	if !reflect.DeepEqual(expectedFeatureGates[2], actual) {
+		t.Fatalf("expected %#v, but got %#v", expectedFeatureGates[2], actual)
+	}
+
+	if actual = kubeoptions.FindByKubeVersion(expectedFeatureGates, "1.30"); actual != nil {
+		t.Fatalf("expected no feature gates for unknown version, but got %#v", actual)
+	}
+}
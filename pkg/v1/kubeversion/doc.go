@@ -11,5 +11,19 @@ Example of getting all supported Kubernetes versions
 	for _, version := range kubeVersions {
 	  fmt.Printf("%+v\n", version)
 	}
+
+Example of planning an upgrade of a cluster to the latest Kubernetes version
+
+	kubeVersions, _, err := kubeversion.List(ctx, mksClient)
+	if err != nil {
+	  log.Fatal(err)
+	}
+	steps, err := kubeversion.PlanUpgrade(mksCluster.KubeVersion, kubeVersions, "")
+	if err != nil {
+	  log.Fatal(err)
+	}
+	for _, step := range steps {
+	  fmt.Printf("%s upgrade: %s -> %s\n", step.Type, step.From, step.To)
+	}
 */
 package kubeversion
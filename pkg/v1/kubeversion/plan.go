@@ -0,0 +1,192 @@
+package kubeversion
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidVersion is returned when a Kubernetes version can't be parsed.
+	ErrInvalidVersion = errors.New("invalid Kubernetes version")
+
+	// ErrDowngrade is returned when the target version is older than the current one.
+	ErrDowngrade = errors.New("downgrade of Kubernetes version isn't supported")
+
+	// ErrNoUpgradePath is returned when the target version can't be reached
+	// with the available Kubernetes versions.
+	ErrNoUpgradePath = errors.New("no upgrade path to the Kubernetes version")
+)
+
+// Version represents a parsed Kubernetes version in "X.Y.Z" format.
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// ParseVersion parses a Kubernetes version in "X.Y.Z" or "vX.Y.Z" format.
+func ParseVersion(s string) (Version, error) {
+	parts := strings.Split(strings.TrimPrefix(s, "v"), ".")
+	if len(parts) != 3 {
+		return Version{}, fmt.Errorf("%w: %q", ErrInvalidVersion, s)
+	}
+
+	numbers := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return Version{}, fmt.Errorf("%w: %q", ErrInvalidVersion, s)
+		}
+		numbers[i] = n
+	}
+
+	return Version{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}, nil
+}
+
+// String returns the version in "X.Y.Z" format.
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// MinorString returns the minor version in "X.Y" format.
+func (v Version) MinorString() string {
+	return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+}
+
+// Compare returns -1, 0 or 1 if the version is older, equal or newer than the other version.
+func (v Version) Compare(other Version) int {
+	switch {
+	case v.Major != other.Major:
+		return compareInts(v.Major, other.Major)
+	case v.Minor != other.Minor:
+		return compareInts(v.Minor, other.Minor)
+	default:
+		return compareInts(v.Patch, other.Patch)
+	}
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// UpgradeType represents custom type for Kubernetes version upgrade types.
+type UpgradeType string
+
+const (
+	// UpgradeTypePatch is an upgrade done with the cluster UpgradePatchVersion request.
+	UpgradeTypePatch UpgradeType = "PATCH"
+
+	// UpgradeTypeMinor is an upgrade done with the cluster UpgradeMinorVersion request.
+	UpgradeTypeMinor UpgradeType = "MINOR"
+)
+
+// UpgradeStep represents a single Kubernetes version upgrade of a cluster.
+type UpgradeStep struct {
+	// Type represents the type of the upgrade.
+	Type UpgradeType
+
+	// From represents the Kubernetes version before the upgrade.
+	From string
+
+	// To represents the Kubernetes version after the upgrade.
+	To string
+}
+
+// PlanUpgrade computes the ordered list of upgrades that are needed to get from
+// the current Kubernetes version to the target one using the versions from the List request.
+// The latest available version is used if the target version is empty.
+//
+// Patch upgrade moves a cluster to the newest patch version of its minor version
+// and minor upgrade moves a cluster to the newest patch version of the next minor version,
+// so only these versions can be reached.
+func PlanUpgrade(current string, versions []*View, target string) ([]*UpgradeStep, error) {
+	currentVersion, err := ParseVersion(current)
+	if err != nil {
+		return nil, err
+	}
+
+	newestPatches, latest, err := newestPatchVersions(versions)
+	if err != nil {
+		return nil, err
+	}
+
+	targetVersion := latest
+	if target == "" && latest.Compare(currentVersion) <= 0 {
+		return []*UpgradeStep{}, nil
+	}
+	if target != "" {
+		if targetVersion, err = ParseVersion(target); err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case targetVersion.Compare(currentVersion) < 0:
+		return nil, fmt.Errorf("%w: %s to %s", ErrDowngrade, currentVersion, targetVersion)
+	case targetVersion.Compare(currentVersion) == 0:
+		return []*UpgradeStep{}, nil
+	case targetVersion.Major != currentVersion.Major:
+		return nil, fmt.Errorf("%w %s: major version upgrades aren't supported", ErrNoUpgradePath, targetVersion)
+	}
+
+	steps := []*UpgradeStep{}
+	for version := currentVersion; version.Compare(targetVersion) < 0; {
+		// Cluster needs to have the newest patch version before the minor version upgrade.
+		newestPatch, ok := newestPatches[version.MinorString()]
+		if ok && newestPatch.Compare(version) > 0 {
+			steps = append(steps, &UpgradeStep{Type: UpgradeTypePatch, From: version.String(), To: newestPatch.String()})
+			version = newestPatch
+
+			continue
+		}
+		if version.Minor == targetVersion.Minor {
+			break
+		}
+
+		nextMinor := Version{Major: version.Major, Minor: version.Minor + 1}
+		next, ok := newestPatches[nextMinor.MinorString()]
+		if !ok {
+			return nil, fmt.Errorf("%w %s: version %s isn't available",
+				ErrNoUpgradePath, targetVersion, nextMinor.MinorString())
+		}
+		steps = append(steps, &UpgradeStep{Type: UpgradeTypeMinor, From: version.String(), To: next.String()})
+		version = next
+	}
+
+	if len(steps) == 0 || steps[len(steps)-1].To != targetVersion.String() {
+		return nil, fmt.Errorf("%w %s: only the newest patch version of a minor version can be reached",
+			ErrNoUpgradePath, targetVersion)
+	}
+
+	return steps, nil
+}
+
+// newestPatchVersions groups versions by minor versions and returns the newest patch version
+// of each of them along with the latest version.
+func newestPatchVersions(versions []*View) (map[string]Version, Version, error) {
+	newestPatches := make(map[string]Version)
+	var latest Version
+	for _, v := range versions {
+		parsed, err := ParseVersion(v.Version)
+		if err != nil {
+			return nil, Version{}, err
+		}
+		if newest, ok := newestPatches[parsed.MinorString()]; !ok || parsed.Compare(newest) > 0 {
+			newestPatches[parsed.MinorString()] = parsed
+		}
+		if parsed.Compare(latest) > 0 {
+			latest = parsed
+		}
+	}
+
+	return newestPatches, latest, nil
+}
@@ -43,3 +43,14 @@ const testManyKubeVersionsInvalidResponseRaw = `
 
 // testErrGenericResponseRaw represents a raw response with an error in the generic format.
 const testErrGenericResponseRaw = `{"error":{"message":"bad gateway"}}`
+
+// testPlanKubeVersions represents Kubernetes versions that are used to plan upgrades.
+var testPlanKubeVersions = []*kubeversion.View{
+	{Version: "1.24.1"},
+	{Version: "1.26.3"},
+	{Version: "1.26.9"},
+	{Version: "1.27.2"},
+	{Version: "1.27.8"},
+	{Version: "1.28.1"},
+	{Version: "1.28.5", IsDefault: true},
+}
@@ -0,0 +1,130 @@
+package testing
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/selectel/mks-go/pkg/v1/kubeversion"
+)
+
+func TestParseVersion(t *testing.T) {
+	actual, err := kubeversion.ParseVersion("v1.28.12")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := kubeversion.Version{Major: 1, Minor: 28, Patch: 12}
+	if actual != expected {
+		t.Fatalf("expected %#v, but got %#v", expected, actual)
+	}
+	if actual.String() != "1.28.12" {
+		t.Fatalf("expected 1.28.12 version string, but got %s", actual.String())
+	}
+	if actual.MinorString() != "1.28" {
+		t.Fatalf("expected 1.28 minor version string, but got %s", actual.MinorString())
+	}
+}
+
+func TestParseVersionInvalid(t *testing.T) {
+	for _, s := range []string{"", "1.28", "1.28.x", "1.28.1.2", "1.-1.0"} {
+		if _, err := kubeversion.ParseVersion(s); !errors.Is(err, kubeversion.ErrInvalidVersion) {
+			t.Fatalf("expected ErrInvalidVersion for %q, but got %v", s, err)
+		}
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	older := kubeversion.Version{Major: 1, Minor: 9, Patch: 10}
+	newer := kubeversion.Version{Major: 1, Minor: 10, Patch: 1}
+
+	if older.Compare(newer) != -1 {
+		t.Fatal("expected 1.9.10 to be older than 1.10.1")
+	}
+	if newer.Compare(older) != 1 {
+		t.Fatal("expected 1.10.1 to be newer than 1.9.10")
+	}
+	if older.Compare(older) != 0 {
+		t.Fatal("expected 1.9.10 to be equal to itself")
+	}
+}
+
+func TestPlanUpgradeToLatest(t *testing.T) {
+	actual, err := kubeversion.PlanUpgrade("1.26.3", testPlanKubeVersions, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*kubeversion.UpgradeStep{
+		{Type: kubeversion.UpgradeTypePatch, From: "1.26.3", To: "1.26.9"},
+		{Type: kubeversion.UpgradeTypeMinor, From: "1.26.9", To: "1.27.8"},
+		{Type: kubeversion.UpgradeTypeMinor, From: "1.27.8", To: "1.28.5"},
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected %#v, but got %#v", expected, actual)
+	}
+}
+
+func TestPlanUpgradeToTarget(t *testing.T) {
+	actual, err := kubeversion.PlanUpgrade("1.26.9", testPlanKubeVersions, "1.27.8")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*kubeversion.UpgradeStep{
+		{Type: kubeversion.UpgradeTypeMinor, From: "1.26.9", To: "1.27.8"},
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected %#v, but got %#v", expected, actual)
+	}
+}
+
+func TestPlanUpgradePatchOnly(t *testing.T) {
+	actual, err := kubeversion.PlanUpgrade("1.28.1", testPlanKubeVersions, "1.28.5")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*kubeversion.UpgradeStep{
+		{Type: kubeversion.UpgradeTypePatch, From: "1.28.1", To: "1.28.5"},
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected %#v, but got %#v", expected, actual)
+	}
+}
+
+func TestPlanUpgradeUpToDate(t *testing.T) {
+	actual, err := kubeversion.PlanUpgrade("1.28.5", testPlanKubeVersions, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(actual) != 0 {
+		t.Fatalf("expected no upgrade steps, but got %#v", actual)
+	}
+}
+
+func TestPlanUpgradeErrors(t *testing.T) {
+	testCases := []struct {
+		current  string
+		target   string
+		expected error
+	}{
+		{current: "1.28.5", target: "1.27.8", expected: kubeversion.ErrDowngrade},
+		{current: "1.26.9", target: "1.27.2", expected: kubeversion.ErrNoUpgradePath},
+		{current: "1.24.1", target: "1.26.9", expected: kubeversion.ErrNoUpgradePath},
+		{current: "1.28.5", target: "2.0.0", expected: kubeversion.ErrNoUpgradePath},
+		{current: "1.28.5", target: "1.28", expected: kubeversion.ErrInvalidVersion},
+	}
+
+	for _, testCase := range testCases {
+		actual, err := kubeversion.PlanUpgrade(testCase.current, testPlanKubeVersions, testCase.target)
+		if !errors.Is(err, testCase.expected) {
+			t.Fatalf("expected %v error for %s -> %s, but got %v",
+				testCase.expected, testCase.current, testCase.target, err)
+		}
+		if actual != nil {
+			t.Fatalf("expected no upgrade steps for %s -> %s, but got %#v",
+				testCase.current, testCase.target, actual)
+		}
+	}
+}
@@ -1,6 +1,11 @@
 package node
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+
+	v1 "github.com/selectel/mks-go/pkg/v1"
+)
 
 // View represents an unmarshalled node body from an API response.
 type View struct {
@@ -24,4 +29,33 @@ type View struct {
 
 	// OSServerID contains OpenStack server identifier.
 	OSServerID string `json:"os_server_id"`
+
+	// Extra contains fields of the API response that are not modelled by the client.
+	// They are kept to be marshalled back.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (result *View) UnmarshalJSON(b []byte) error {
+	type tmp View
+	var s tmp
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	*result = View(s)
+
+	extra, err := v1.ExtractExtraFields(b, result)
+	if err != nil {
+		return err
+	}
+	result.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON marshals the node with its extra fields.
+func (result View) MarshalJSON() ([]byte, error) {
+	type tmp View
+
+	return v1.MarshalWithExtraFields(tmp(result), result.Extra)
 }
@@ -0,0 +1,58 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/selectel/mks-go/pkg/testutils"
+	v1 "github.com/selectel/mks-go/pkg/v1"
+	"github.com/selectel/mks-go/pkg/v1/node"
+	"github.com/selectel/mks-go/pkg/v1/task"
+)
+
+func TestReinstallAndTrack(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	clusterID := "792de51c-3700-49fa-af0e-7f547bce788a"
+	nodegroupID := "f174b65d-442a-4423-aaf7-5654789b8a9d"
+	nodeID := "203d0f8c-547d-48a7-98ed-3075254b8d4a"
+
+	fakeTasks := testutils.HandleFakeTasks(testEnv.Mux, clusterID)
+	testEnv.Mux.HandleFunc(fmt.Sprintf("/v1/clusters/%s/nodegroups/%s/%s/reinstall", clusterID, nodegroupID, nodeID),
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				t.Fatalf("expected %s method but got %s", http.MethodPost, r.Method)
+			}
+			fakeTasks.Add("reinstall-1", string(task.TypeNodeReinstall), string(task.StatusDone), nodegroupID)
+			fakeTasks.Add("reinstall-2", string(task.TypeNodeReinstall), string(task.StatusDone), nodegroupID)
+			w.Header().Set(task.TaskIDHeader, "reinstall-2")
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+	ctx := context.Background()
+	testClient := &v1.ServiceClient{
+		HTTPClient: &http.Client{},
+		TokenID:    testutils.TokenID,
+		Endpoint:   testEnv.Server.URL + "/v1",
+		UserAgent:  testutils.UserAgent,
+	}
+
+	operation, _, err := node.ReinstallAndTrack(ctx, testClient, clusterID, nodegroupID, nodeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if operation.TaskID() != "reinstall-2" {
+		t.Fatalf("expected reinstall-2 task id from the header, but got %q", operation.TaskID())
+	}
+
+	actual, err := operation.Wait(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual.ID != "reinstall-2" {
+		t.Fatalf("expected reinstall-2 task, but got %s", actual.ID)
+	}
+}
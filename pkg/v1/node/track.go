@@ -0,0 +1,27 @@
+package node
+
+import (
+	"context"
+
+	v1 "github.com/selectel/mks-go/pkg/v1"
+	"github.com/selectel/mks-go/pkg/v1/task"
+)
+
+// ReinstallAndTrack requests a reinstallation of a single node in the cluster nodegroup
+// and returns an operation that tracks the spawned task.
+func ReinstallAndTrack(ctx context.Context, client *v1.ServiceClient, clusterID, nodegroupID,
+	nodeID string,
+) (*task.Operation, *v1.ResponseResult, error) {
+	operation, err := task.NewOperation(ctx, client, clusterID, task.TypeNodeReinstall, nodegroupID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responseResult, err := Reinstall(ctx, client, clusterID, nodegroupID, nodeID)
+	if err != nil {
+		return nil, responseResult, err
+	}
+	operation.SetResponse(responseResult)
+
+	return operation, responseResult, nil
+}
@@ -0,0 +1,189 @@
+package nodegroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	v1 "github.com/selectel/mks-go/pkg/v1"
+)
+
+var (
+	// ErrInvalidAutoscalePolicy is returned when an autoscale policy breaks its invariants.
+	ErrInvalidAutoscalePolicy = errors.New("invalid autoscale policy")
+
+	// ErrResizeOutOfAutoscaleBounds is returned when an autoscaled nodegroup is resized
+	// to a nodes count outside the autoscale bounds.
+	ErrResizeOutOfAutoscaleBounds = errors.New("desired nodes count is out of autoscale bounds")
+)
+
+// AutoscalePolicy represents autoscale settings of a nodegroup.
+type AutoscalePolicy struct {
+	// Enabled reflects if the nodegroup is allowed to be scaled automatically.
+	Enabled bool
+
+	// MinNodes represents minimum possible number of worker nodes in the nodegroup.
+	MinNodes int
+
+	// MaxNodes represents maximum possible number of worker nodes in the nodegroup.
+	MaxNodes int
+}
+
+// Validate checks the policy invariants against the provided nodes count.
+// A disabled policy is always valid.
+func (policy AutoscalePolicy) Validate(count int) error {
+	if !policy.Enabled {
+		return nil
+	}
+	if policy.MinNodes < 0 || policy.MaxNodes < 1 {
+		return fmt.Errorf("%w: min nodes can't be negative and max nodes should be positive", ErrInvalidAutoscalePolicy)
+	}
+	if policy.MinNodes > policy.MaxNodes {
+		return fmt.Errorf("%w: min nodes %d is greater than max nodes %d",
+			ErrInvalidAutoscalePolicy, policy.MinNodes, policy.MaxNodes)
+	}
+	if !policy.Contains(count) {
+		return fmt.Errorf("%w: nodes count %d is out of [%d, %d]",
+			ErrInvalidAutoscalePolicy, count, policy.MinNodes, policy.MaxNodes)
+	}
+
+	return nil
+}
+
+// Contains returns true if the policy is disabled or the nodes count is within its bounds.
+func (policy AutoscalePolicy) Contains(count int) bool {
+	return !policy.Enabled || (count >= policy.MinNodes && count <= policy.MaxNodes)
+}
+
+// Clamp returns the nodes count moved within the policy bounds if the policy is enabled.
+func (policy AutoscalePolicy) Clamp(count int) int {
+	if !policy.Enabled {
+		return count
+	}
+	if count < policy.MinNodes {
+		return policy.MinNodes
+	}
+	if count > policy.MaxNodes {
+		return policy.MaxNodes
+	}
+
+	return count
+}
+
+// ApplyToCreateOpts sets autoscale fields of the nodegroup Create request options.
+func (policy AutoscalePolicy) ApplyToCreateOpts(opts *CreateOpts) {
+	opts.EnableAutoscale, opts.AutoscaleMinNodes, opts.AutoscaleMaxNodes = policy.fields()
+}
+
+// ApplyToUpdateOpts sets autoscale fields of the nodegroup Update request options.
+func (policy AutoscalePolicy) ApplyToUpdateOpts(opts *UpdateOpts) {
+	opts.EnableAutoscale, opts.AutoscaleMinNodes, opts.AutoscaleMaxNodes = policy.fields()
+}
+
+// fields returns values of the autoscale request fields. Bounds are omitted for a disabled policy.
+func (policy AutoscalePolicy) fields() (*bool, *int, *int) {
+	enabled := policy.Enabled
+	if !enabled {
+		return &enabled, nil, nil
+	}
+	minNodes, maxNodes := policy.MinNodes, policy.MaxNodes
+
+	return &enabled, &minNodes, &maxNodes
+}
+
+// AutoscalePolicy returns the autoscale policy of the nodegroup.
+func (result *BaseView) AutoscalePolicy() AutoscalePolicy {
+	return AutoscalePolicy{
+		Enabled:  result.EnableAutoscale,
+		MinNodes: result.AutoscaleMinNodes,
+		MaxNodes: result.AutoscaleMaxNodes,
+	}
+}
+
+// AutoscalePolicy returns the autoscale policy of the nodegroup Create request options.
+func (opts *CreateOpts) AutoscalePolicy() AutoscalePolicy {
+	return newAutoscalePolicy(opts.EnableAutoscale, opts.AutoscaleMinNodes, opts.AutoscaleMaxNodes)
+}
+
+// AutoscalePolicy returns the autoscale policy of the nodegroup Update request options.
+func (opts *UpdateOpts) AutoscalePolicy() AutoscalePolicy {
+	return newAutoscalePolicy(opts.EnableAutoscale, opts.AutoscaleMinNodes, opts.AutoscaleMaxNodes)
+}
+
+func newAutoscalePolicy(enabled *bool, minNodes, maxNodes *int) AutoscalePolicy {
+	var policy AutoscalePolicy
+	if enabled != nil {
+		policy.Enabled = *enabled
+	}
+	if minNodes != nil {
+		policy.MinNodes = *minNodes
+	}
+	if maxNodes != nil {
+		policy.MaxNodes = *maxNodes
+	}
+
+	return policy
+}
+
+// EnableAutoscale enables autoscaling in the nodegroup Create request options.
+// Count is moved within the provided bounds.
+func EnableAutoscale(opts *CreateOpts, minNodes, maxNodes int) error {
+	policy := AutoscalePolicy{
+		Enabled:  true,
+		MinNodes: minNodes,
+		MaxNodes: maxNodes,
+	}
+	count := policy.Clamp(opts.Count)
+	if err := policy.Validate(count); err != nil {
+		return err
+	}
+
+	opts.Count = count
+	policy.ApplyToCreateOpts(opts)
+
+	return nil
+}
+
+// DisableAutoscale disables autoscaling in the nodegroup Create request options.
+// Count is set to the previous minimum nodes count if it isn't set.
+func DisableAutoscale(opts *CreateOpts) {
+	if opts.Count == 0 && opts.AutoscaleMinNodes != nil {
+		opts.Count = *opts.AutoscaleMinNodes
+	}
+	AutoscalePolicy{}.ApplyToCreateOpts(opts)
+}
+
+// ResizeChecked requests a resize of a cluster nodegroup like Resize, but retrieves
+// the nodegroup first and returns ErrResizeOutOfAutoscaleBounds without the resize request
+// if the nodegroup is autoscaled and the desired amount of nodes is out of the autoscale bounds.
+// The returned ResponseResult is the result of the nodegroup Get request if the check fails,
+// otherwise it's the result of the resize request.
+func ResizeChecked(ctx context.Context, client *v1.ServiceClient, clusterID, nodegroupID string,
+	opts *ResizeOpts,
+) (*v1.ResponseResult, error) {
+	if opts != nil {
+		if responseResult, err := checkResize(ctx, client, clusterID, nodegroupID, opts); err != nil {
+			return responseResult, err
+		}
+	}
+
+	return Resize(ctx, client, clusterID, nodegroupID, opts)
+}
+
+// checkResize returns ErrResizeOutOfAutoscaleBounds if the nodegroup is autoscaled
+// and the desired nodes count is out of the autoscale bounds. The result of the nodegroup
+// Get request is returned along with the error.
+func checkResize(ctx context.Context, client *v1.ServiceClient, clusterID, nodegroupID string,
+	opts *ResizeOpts,
+) (*v1.ResponseResult, error) {
+	mksNodegroup, responseResult, err := Get(ctx, client, clusterID, nodegroupID)
+	if err != nil {
+		return responseResult, err
+	}
+	if policy := mksNodegroup.AutoscalePolicy(); !policy.Contains(opts.Desired) {
+		return responseResult, fmt.Errorf("%w: %d is out of [%d, %d]",
+			ErrResizeOutOfAutoscaleBounds, opts.Desired, policy.MinNodes, policy.MaxNodes)
+	}
+
+	return nil, nil
+}
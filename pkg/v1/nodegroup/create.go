@@ -0,0 +1,152 @@
+package nodegroup
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strings"
+	"time"
+
+	v1 "github.com/selectel/mks-go/pkg/v1"
+	"github.com/selectel/mks-go/pkg/v1/task"
+)
+
+// DefaultCreateDiscoveryAttempts represents the default number of nodegroups list requests
+// used to find the created nodegroup when its id isn't provided in the response.
+const DefaultCreateDiscoveryAttempts = 5
+
+var (
+	// ErrCreatedNodegroupNotFound is returned when the created nodegroup can't be found.
+	ErrCreatedNodegroupNotFound = errors.New("created nodegroup not found")
+
+	// ErrCreatedNodegroupAmbiguous is returned when several new nodegroups appeared
+	// in the cluster and the created one can't be told apart.
+	ErrCreatedNodegroupAmbiguous = errors.New("created nodegroup is ambiguous")
+)
+
+// CreateAndGetOpts represents options for the CreateAndGet request.
+type CreateAndGetOpts struct {
+	// WaitForActive enables waiting until the created nodegroup has the active status.
+	WaitForActive bool
+
+	// PollInterval represents the interval between requests when looking for the created
+	// nodegroup or waiting for it. task.DefaultPollInterval is used if it's not set.
+	PollInterval time.Duration
+
+	// DiscoveryAttempts represents the number of nodegroups list requests used to find
+	// the created nodegroup. DefaultCreateDiscoveryAttempts is used if it's not set.
+	DiscoveryAttempts int
+}
+
+// CreateAndGet requests a creation of a new cluster nodegroup and returns the created nodegroup.
+// The nodegroup id is read from the response body, then from the Location header. If the response
+// has neither, the id is found by comparing the nodegroups list before and after the request.
+// The returned ResponseResult is the result of the creation request.
+func CreateAndGet(ctx context.Context, client *v1.ServiceClient, clusterID string, opts *CreateOpts,
+	getOpts *CreateAndGetOpts,
+) (*GetView, *v1.ResponseResult, error) {
+	if getOpts == nil {
+		getOpts = &CreateAndGetOpts{}
+	}
+	interval := getOpts.PollInterval
+	if interval <= 0 {
+		interval = task.DefaultPollInterval
+	}
+
+	previous, _, err := List(ctx, client, clusterID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responseResult, err := Create(ctx, client, clusterID, opts)
+	if err != nil {
+		return nil, responseResult, err
+	}
+
+	nodegroupID := createdNodegroupID(responseResult)
+	if nodegroupID == "" {
+		nodegroupID, err = discoverNodegroupID(ctx, client, clusterID, previous, interval, getOpts.DiscoveryAttempts)
+		if err != nil {
+			return nil, responseResult, err
+		}
+	}
+
+	if getOpts.WaitForActive {
+		mksNodegroup, err := WaitForActive(ctx, client, clusterID, nodegroupID, interval)
+
+		return mksNodegroup, responseResult, err
+	}
+
+	mksNodegroup, _, err := Get(ctx, client, clusterID, nodegroupID)
+	if err != nil {
+		return nil, responseResult, err
+	}
+
+	return mksNodegroup, responseResult, nil
+}
+
+// createdNodegroupID returns the id of the created nodegroup from the response body
+// or the Location header. Empty string is returned if the response has neither.
+func createdNodegroupID(responseResult *v1.ResponseResult) string {
+	if responseResult == nil || responseResult.Response == nil {
+		return ""
+	}
+
+	if responseResult.Body != nil {
+		var result struct {
+			Nodegroup *struct {
+				ID string `json:"id"`
+			} `json:"nodegroup"`
+		}
+		if err := responseResult.ExtractResult(&result); err == nil && result.Nodegroup != nil && result.Nodegroup.ID != "" {
+			return result.Nodegroup.ID
+		}
+	}
+
+	location := strings.TrimSuffix(responseResult.Header.Get("Location"), "/")
+	if location == "" {
+		return ""
+	}
+
+	return path.Base(location)
+}
+
+// discoverNodegroupID finds the id of the nodegroup that is absent in the previous nodegroups list.
+func discoverNodegroupID(ctx context.Context, client *v1.ServiceClient, clusterID string, previous []*ListView,
+	interval time.Duration, attempts int,
+) (string, error) {
+	if attempts <= 0 {
+		attempts = DefaultCreateDiscoveryAttempts
+	}
+	known := make(map[string]struct{}, len(previous))
+	for _, ng := range previous {
+		known[ng.ID] = struct{}{}
+	}
+
+	for attempt := 0; ; attempt++ {
+		current, _, err := List(ctx, client, clusterID)
+		if err != nil {
+			return "", err
+		}
+
+		var created []string
+		for _, ng := range current {
+			if _, ok := known[ng.ID]; !ok {
+				created = append(created, ng.ID)
+			}
+		}
+		if len(created) == 1 {
+			return created[0], nil
+		}
+		if len(created) > 1 {
+			return "", ErrCreatedNodegroupAmbiguous
+		}
+
+		if attempt+1 >= attempts {
+			return "", ErrCreatedNodegroupNotFound
+		}
+		if err := v1.Sleep(ctx, interval); err != nil {
+			return "", err
+		}
+	}
+}
@@ -55,6 +55,12 @@ Example of deleting a single cluster nodegroup
 	  log.Fatal(err)
 	}
 
+Example of checking that a nodegroup can be resized before sending the request
+
+	if !clusterNodegroup.Status.CanPerform(nodegroup.OperationResize) {
+	  log.Fatalf("nodegroup can't be resized in the %s status", clusterNodegroup.Status)
+	}
+
 Example of resizing a single cluster nodegroup
 
 	resizeOpts := &nodegroup.ResizeOpts{
@@ -94,5 +100,141 @@ Example of updating nodegroup taints
 	if err != nil {
 	  log.Fatal(err)
 	}
+
+Example of resizing a cluster nodegroup and waiting for the spawned task
+
+	resizeOpts := &nodegroup.ResizeOpts{
+	  Desired: 3,
+	}
+	operation, _, err := nodegroup.ResizeAndTrack(ctx, mksClient, clusterID, nodegroupID, resizeOpts)
+	if err != nil {
+	  log.Fatal(err)
+	}
+	resizeTask, err := operation.Wait(ctx)
+	if err != nil {
+	  log.Fatal(err)
+	}
+	fmt.Printf("%+v\n", resizeTask)
+
+Example of creating a cluster nodegroup and waiting until it's active
+
+	createAndGetOpts := &nodegroup.CreateAndGetOpts{
+	  WaitForActive: true,
+	}
+	mksNodegroup, _, err := nodegroup.CreateAndGet(ctx, mksClient, clusterID, createOpts, createAndGetOpts)
+	if err != nil {
+	  log.Fatal(err)
+	}
+	fmt.Printf("%+v\n", mksNodegroup)
+
+Example of reinstalling all nodes of a cluster nodegroup two at a time
+
+	reinstallOpts := &nodegroup.RollingReinstallOpts{
+	  BatchSize:      4,
+	  MaxUnavailable: 2,
+	}
+	state, err := nodegroup.RollingReinstall(ctx, mksClient, clusterID, nodegroupID, reinstallOpts)
+	if err != nil {
+	  // The state can be passed with reinstallOpts.State to resume the reinstall.
+	  log.Fatalf("reinstalled nodes: %v, failed nodes: %v: %s", state.Done, state.Failed, err)
+	}
+
+Example of replacing a cluster nodegroup with a nodegroup of another flavor
+
+	replaceOpts := &nodegroup.ReplaceOpts{
+	  Modify: func(opts *nodegroup.CreateOpts) {
+	    opts.FlavorID = "3011"
+	  },
+	  DrainHook: nodegroup.DrainHookFunc(func(ctx context.Context, oldNodegroup, newNodegroup *nodegroup.GetView) error {
+	    // Cordon and drain nodes of the old nodegroup.
+	    return nil
+	  }),
+	}
+	newNodegroup, err := nodegroup.Replace(ctx, mksClient, clusterID, nodegroupID, replaceOpts)
+	if err != nil {
+	  log.Fatal(err)
+	}
+	fmt.Printf("%+v\n", newNodegroup)
+
+Example of adding a label to a cluster nodegroup and removing a taint from it
+
+	labelsPatch := &nodegroup.LabelsPatch{
+	  Add: map[string]string{
+	    "team": "core",
+	  },
+	}
+	_, err := nodegroup.PatchLabels(ctx, mksClient, clusterID, nodegroupID, labelsPatch)
+	if err != nil {
+	  log.Fatal(err)
+	}
+	taintsPatch := &nodegroup.TaintsPatch{
+	  Remove: []nodegroup.Taint{
+	    {
+	      Key: "dedicated",
+	    },
+	  },
+	  // The update time of the nodegroup that has been reviewed before the patch.
+	  ExpectedUpdatedAt: reviewedNodegroup.UpdatedAt,
+	}
+	_, err = nodegroup.PatchTaints(ctx, mksClient, clusterID, nodegroupID, taintsPatch)
+	if errors.Is(err, nodegroup.ErrConcurrentModification) {
+	  // Review the nodegroup again.
+	}
+
+Example of enabling autoscaling of a cluster nodegroup
+
+	policy := nodegroup.AutoscalePolicy{
+	  Enabled:  true,
+	  MinNodes: 1,
+	  MaxNodes: 5,
+	}
+	mksNodegroup, _, err := nodegroup.Get(ctx, mksClient, clusterID, nodegroupID)
+	if err != nil {
+	  log.Fatal(err)
+	}
+	if err := policy.Validate(len(mksNodegroup.Nodes)); err != nil {
+	  log.Fatal(err)
+	}
+	updateOpts := &nodegroup.UpdateOpts{}
+	policy.ApplyToUpdateOpts(updateOpts)
+	_, err = nodegroup.Update(ctx, mksClient, clusterID, nodegroupID, updateOpts)
+	if err != nil {
+	  log.Fatal(err)
+	}
+
+Example of resizing a cluster nodegroup only within its autoscale bounds
+
+	resizeOpts := &nodegroup.ResizeOpts{
+	  Desired: 4,
+	}
+	_, err := nodegroup.ResizeChecked(ctx, mksClient, clusterID, nodegroupID, resizeOpts)
+	if errors.Is(err, nodegroup.ErrResizeOutOfAutoscaleBounds) {
+	  log.Fatal("change the autoscale bounds first")
+	}
+	if err != nil {
+	  log.Fatal(err)
+	}
+
+Example of spreading nodes across availability zones and restoring the balance later
+
+	zones := []string{"ru-3a", "ru-3b", "ru-3c"}
+	plan, err := nodegroup.PlanSpread(7, zones, createOpts)
+	if err != nil {
+	  log.Fatal(err)
+	}
+	for _, zoneOpts := range plan {
+	  _, err := nodegroup.Create(ctx, mksClient, clusterID, zoneOpts)
+	  if err != nil {
+	    log.Fatal(err)
+	  }
+	}
+
+	calls, err := nodegroup.Rebalance(ctx, mksClient, clusterID, nil)
+	if err != nil {
+	  log.Fatal(err)
+	}
+	for _, call := range calls {
+	  fmt.Printf("%s: %d -> %d\n", call.AvailabilityZone, call.Current, call.Opts.Desired)
+	}
 */
 package nodegroup
@@ -0,0 +1,21 @@
+package nodegroup
+
+import (
+	"strings"
+
+	v1 "github.com/selectel/mks-go/pkg/v1"
+	"github.com/selectel/mks-go/pkg/v1/internal/paging"
+)
+
+// Pager iterates over nodegroups of a cluster.
+type Pager = paging.Pager[*ListView]
+
+// NewPager returns a pager over nodegroups of a cluster that requests pages of the provided size.
+// All nodegroups are requested at once if the limit isn't positive.
+func NewPager(client *v1.ServiceClient, clusterID string, limit int) *Pager {
+	url := strings.Join([]string{client.Endpoint, v1.ResourceURLCluster, clusterID, v1.ResourceURLNodegroup}, "/")
+
+	return paging.NewPager(client, url, "nodegroups", limit, func(nodegroupView *ListView) string {
+		return nodegroupView.ID
+	})
+}
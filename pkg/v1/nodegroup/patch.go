@@ -0,0 +1,183 @@
+package nodegroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	v1 "github.com/selectel/mks-go/pkg/v1"
+)
+
+// ErrConcurrentModification is returned when a nodegroup has been updated after the version
+// a patch is based on.
+var ErrConcurrentModification = errors.New("nodegroup has been modified concurrently")
+
+// LabelsPatch represents changes of nodegroup labels.
+// Replace is applied first, then Remove and Add.
+type LabelsPatch struct {
+	// Replace replaces all labels of the nodegroup if it's not nil.
+	Replace map[string]string
+
+	// Remove contains keys of labels to remove.
+	Remove []string
+
+	// Add contains labels to add. Existing labels with the same keys are overwritten.
+	Add map[string]string
+
+	// ExpectedUpdatedAt contains the update time of the nodegroup the patch is based on.
+	// PatchLabels returns ErrConcurrentModification if the nodegroup has a different update
+	// time. The update time of the nodegroup read by PatchLabels is used if it's nil.
+	ExpectedUpdatedAt *time.Time
+}
+
+// Apply returns a copy of the provided labels with the patch applied.
+func (patch *LabelsPatch) Apply(labels map[string]string) map[string]string {
+	if patch.Replace != nil {
+		labels = patch.Replace
+	}
+
+	result := make(map[string]string, len(labels)+len(patch.Add))
+	for k, v := range labels {
+		result[k] = v
+	}
+	for _, k := range patch.Remove {
+		delete(result, k)
+	}
+	for k, v := range patch.Add {
+		result[k] = v
+	}
+
+	return result
+}
+
+// TaintsPatch represents changes of nodegroup taints. Taints are identified by the key
+// and the effect. Replace is applied first, then Remove and Add.
+type TaintsPatch struct {
+	// Replace replaces all taints of the nodegroup if it's not nil.
+	Replace []Taint
+
+	// Remove contains taints to remove. Values of the taints are ignored and
+	// a taint with an empty effect removes taints with the same key and any effect.
+	Remove []Taint
+
+	// Add contains taints to add. Existing taints with the same key and effect are overwritten.
+	Add []Taint
+
+	// ExpectedUpdatedAt contains the update time of the nodegroup the patch is based on.
+	// PatchTaints returns ErrConcurrentModification if the nodegroup has a different update
+	// time. The update time of the nodegroup read by PatchTaints is used if it's nil.
+	ExpectedUpdatedAt *time.Time
+}
+
+// Apply returns a copy of the provided taints with the patch applied.
+func (patch *TaintsPatch) Apply(taints []Taint) []Taint {
+	if patch.Replace != nil {
+		taints = patch.Replace
+	}
+
+	result := make([]Taint, 0, len(taints)+len(patch.Add))
+	for _, taint := range taints {
+		if !patch.removes(taint) {
+			result = append(result, taint)
+		}
+	}
+	for _, added := range patch.Add {
+		result = setTaint(result, added)
+	}
+
+	return result
+}
+
+// removes returns true if the taint matches one of the removed taints.
+func (patch *TaintsPatch) removes(taint Taint) bool {
+	for _, removed := range patch.Remove {
+		if removed.Key == taint.Key && (removed.Effect == "" || removed.Effect == taint.Effect) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// setTaint overwrites a taint with the same key and effect or appends the taint.
+func setTaint(taints []Taint, taint Taint) []Taint {
+	for i := range taints {
+		if taints[i].Key == taint.Key && taints[i].Effect == taint.Effect {
+			taints[i] = taint
+
+			return taints
+		}
+	}
+
+	return append(taints, taint)
+}
+
+// PatchLabels applies the patch to labels of a cluster nodegroup. ErrConcurrentModification
+// is returned if the nodegroup update time differs from LabelsPatch.ExpectedUpdatedAt.
+// If it isn't set, the patch is computed from the nodegroup that is read first and
+// the nodegroup is read again before the update to compare their update times.
+// The MKS V1 API doesn't support conditional updates, so an update made between
+// the last read and the write is still overwritten: the check only narrows the race.
+func PatchLabels(ctx context.Context, client *v1.ServiceClient, clusterID, nodegroupID string,
+	patch *LabelsPatch,
+) (*v1.ResponseResult, error) {
+	return patchNodegroup(ctx, client, clusterID, nodegroupID, patch.ExpectedUpdatedAt,
+		func(mksNodegroup *GetView) *UpdateOpts {
+			return &UpdateOpts{
+				Labels: patch.Apply(mksNodegroup.Labels),
+				Taints: mksNodegroup.Taints,
+			}
+		})
+}
+
+// PatchTaints applies the patch to taints of a cluster nodegroup. ErrConcurrentModification
+// is returned if the nodegroup update time differs from TaintsPatch.ExpectedUpdatedAt or,
+// if it isn't set, from the update time of the nodegroup the patch is computed from.
+// As with PatchLabels, the check can only detect races, not prevent them.
+func PatchTaints(ctx context.Context, client *v1.ServiceClient, clusterID, nodegroupID string,
+	patch *TaintsPatch,
+) (*v1.ResponseResult, error) {
+	return patchNodegroup(ctx, client, clusterID, nodegroupID, patch.ExpectedUpdatedAt,
+		func(mksNodegroup *GetView) *UpdateOpts {
+			return &UpdateOpts{
+				Labels: mksNodegroup.Labels,
+				Taints: patch.Apply(mksNodegroup.Taints),
+			}
+		})
+}
+
+// patchNodegroup reads a nodegroup, builds the update from it and checks the update time
+// before sending the update. Without the expected update time the nodegroup is read again
+// and compared with the first read.
+func patchNodegroup(ctx context.Context, client *v1.ServiceClient, clusterID, nodegroupID string,
+	expectedUpdatedAt *time.Time, buildOpts func(mksNodegroup *GetView) *UpdateOpts,
+) (*v1.ResponseResult, error) {
+	mksNodegroup, responseResult, err := Get(ctx, client, clusterID, nodegroupID)
+	if err != nil {
+		return responseResult, err
+	}
+	updateOpts := buildOpts(mksNodegroup)
+	if expectedUpdatedAt == nil {
+		expectedUpdatedAt = mksNodegroup.UpdatedAt
+		mksNodegroup, responseResult, err = Get(ctx, client, clusterID, nodegroupID)
+		if err != nil {
+			return responseResult, err
+		}
+	}
+	if !sameTime(expectedUpdatedAt, mksNodegroup.UpdatedAt) {
+		return responseResult, fmt.Errorf("%w: %s has been updated at %v", ErrConcurrentModification,
+			nodegroupID, mksNodegroup.UpdatedAt)
+	}
+
+	return Update(ctx, client, clusterID, nodegroupID, updateOpts)
+}
+
+// sameTime returns true if both timestamps are nil or equal.
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Equal(*b)
+}
@@ -0,0 +1,177 @@
+package nodegroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	v1 "github.com/selectel/mks-go/pkg/v1"
+	"github.com/selectel/mks-go/pkg/v1/node"
+	"github.com/selectel/mks-go/pkg/v1/task"
+)
+
+// ErrInvalidRollingReinstallOpts is returned when rolling reinstall options are invalid.
+var ErrInvalidRollingReinstallOpts = errors.New("invalid rolling reinstall options")
+
+// RollingReinstallOpts represents options for the RollingReinstall request.
+type RollingReinstallOpts struct {
+	// BatchSize represents the number of nodes reinstalled before waiting for the nodegroup
+	// to return to the active status. Defaults to 1.
+	BatchSize int
+
+	// MaxUnavailable represents the maximum number of nodes reinstalled at the same time
+	// within a batch. Defaults to 1. It's limited by BatchSize.
+	MaxUnavailable int
+
+	// PollInterval represents the interval between requests when waiting for tasks
+	// and the nodegroup. task.DefaultPollInterval is used if it's not set.
+	PollInterval time.Duration
+
+	// State contains the state of a previous interrupted run. Nodes that are already done
+	// are skipped. A new state is used if it's not set.
+	State *RollingReinstallState
+}
+
+// RollingReinstallState represents the progress of a rolling reinstall.
+// It can be persisted and passed back with RollingReinstallOpts to resume the reinstall.
+type RollingReinstallState struct {
+	// Done contains identifiers of the reinstalled nodes.
+	Done []string `json:"done"`
+
+	// Failed contains identifiers of the nodes whose reinstall has failed
+	// or has been interrupted. They are reinstalled again on resume.
+	Failed []string `json:"failed,omitempty"`
+}
+
+// IsDone returns true if the node referenced by its id is already reinstalled.
+func (state *RollingReinstallState) IsDone(nodeID string) bool {
+	for _, id := range state.Done {
+		if id == nodeID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RollingReinstall reinstalls every node of a cluster nodegroup in batches.
+// Nodes of a batch are reinstalled in groups of MaxUnavailable nodes, each group waits
+// for its reinstall tasks. After every batch RollingReinstall waits for the nodegroup
+// to return to the active status. It stops on the first failure and returns the state
+// that can be used to resume the reinstall along with the error.
+func RollingReinstall(ctx context.Context, client *v1.ServiceClient, clusterID, nodegroupID string,
+	opts *RollingReinstallOpts,
+) (*RollingReinstallState, error) {
+	if opts == nil {
+		opts = &RollingReinstallOpts{}
+	}
+	if opts.BatchSize < 0 || opts.MaxUnavailable < 0 {
+		return nil, fmt.Errorf("%w: batch size and max unavailable can't be negative", ErrInvalidRollingReinstallOpts)
+	}
+	state := opts.State
+	if state == nil {
+		state = &RollingReinstallState{}
+	}
+	state.Failed = nil
+	batchSize, groupSize := opts.sizes()
+
+	mksNodegroup, err := WaitForActive(ctx, client, clusterID, nodegroupID, opts.PollInterval)
+	if err != nil {
+		return state, err
+	}
+
+	pending := state.pending(mksNodegroup.Nodes)
+	for len(pending) > 0 {
+		batch := pending
+		if len(batch) > batchSize {
+			batch = batch[:batchSize]
+		}
+		pending = pending[len(batch):]
+
+		if err := state.reinstallBatch(ctx, client, clusterID, nodegroupID, batch, groupSize, opts.PollInterval); err != nil {
+			return state, err
+		}
+		if _, err := WaitForActive(ctx, client, clusterID, nodegroupID, opts.PollInterval); err != nil {
+			return state, err
+		}
+	}
+
+	return state, nil
+}
+
+// pending returns identifiers of the nodes that are not reinstalled yet.
+func (state *RollingReinstallState) pending(nodes []*node.View) []string {
+	var pending []string
+	for _, n := range nodes {
+		if !state.IsDone(n.ID) {
+			pending = append(pending, n.ID)
+		}
+	}
+
+	return pending
+}
+
+// reinstallBatch reinstalls nodes of the batch in groups of the provided size and records
+// the progress in the state.
+func (state *RollingReinstallState) reinstallBatch(ctx context.Context, client *v1.ServiceClient,
+	clusterID, nodegroupID string, batch []string, groupSize int, interval time.Duration,
+) error {
+	for len(batch) > 0 {
+		group := batch
+		if len(group) > groupSize {
+			group = group[:groupSize]
+		}
+		batch = batch[len(group):]
+
+		if err := reinstallNodes(ctx, client, clusterID, nodegroupID, group, interval); err != nil {
+			state.Failed = group
+
+			return err
+		}
+		state.Done = append(state.Done, group...)
+	}
+
+	return nil
+}
+
+// sizes returns batch and group sizes with defaults applied.
+func (opts *RollingReinstallOpts) sizes() (int, int) {
+	batchSize, groupSize := opts.BatchSize, opts.MaxUnavailable
+	if batchSize == 0 {
+		batchSize = 1
+	}
+	if groupSize == 0 {
+		groupSize = 1
+	}
+	if groupSize > batchSize {
+		groupSize = batchSize
+	}
+
+	return batchSize, groupSize
+}
+
+// reinstallNodes requests reinstalls of the provided nodes and waits for the spawned tasks.
+// Tasks that have been started before the request of a node aren't matched to it.
+func reinstallNodes(ctx context.Context, client *v1.ServiceClient, clusterID, nodegroupID string,
+	nodeIDs []string, interval time.Duration,
+) error {
+	operations := make([]*task.Operation, 0, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		op, _, err := node.ReinstallAndTrack(ctx, client, clusterID, nodegroupID, nodeID)
+		if err != nil {
+			return fmt.Errorf("reinstall node %s: %w", nodeID, err)
+		}
+		op.PollInterval = interval
+		operations = append(operations, op)
+	}
+
+	if len(operations) == 1 {
+		_, err := operations[0].Wait(ctx)
+
+		return err
+	}
+	_, err := task.WaitAll(ctx, operations...)
+
+	return err
+}
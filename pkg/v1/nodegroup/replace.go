@@ -0,0 +1,188 @@
+package nodegroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	v1 "github.com/selectel/mks-go/pkg/v1"
+)
+
+// ReplaceRollbackTimeout represents the timeout of the deletion request that rolls back
+// a failed replacement. The deletion doesn't use the Replace context, so it's sent even
+// if the context is done.
+const ReplaceRollbackTimeout = time.Minute
+
+// ErrReplaceRolledBack is returned when the new nodegroup has failed to come up
+// and has been deleted. The old nodegroup is kept untouched.
+var ErrReplaceRolledBack = errors.New("nodegroup replacement has been rolled back")
+
+// ReplaceRollbackError is returned when the new nodegroup has the error status.
+// It matches ErrReplaceRolledBack if the new nodegroup deletion has been requested.
+type ReplaceRollbackError struct {
+	// NodegroupID contains the identifier of the new nodegroup.
+	NodegroupID string
+
+	// Reason contains the error of the new nodegroup.
+	Reason error
+
+	// DeleteErr contains the error of the new nodegroup deletion. It's nil if the deletion
+	// has been requested.
+	DeleteErr error
+}
+
+func (e *ReplaceRollbackError) Error() string {
+	if e.DeleteErr != nil {
+		return fmt.Sprintf("delete nodegroup %s after %v: %v", e.NodegroupID, e.Reason, e.DeleteErr)
+	}
+
+	return fmt.Sprintf("%v: %v", ErrReplaceRolledBack, e.Reason)
+}
+
+// Unwrap returns the error of the new nodegroup.
+func (e *ReplaceRollbackError) Unwrap() error {
+	return e.Reason
+}
+
+// Is reports if the error matches ErrReplaceRolledBack.
+func (e *ReplaceRollbackError) Is(target error) bool {
+	return target == ErrReplaceRolledBack && e.DeleteErr == nil
+}
+
+// DrainHook is implemented by callers that move workloads from the old nodegroup
+// to the new one before the old nodegroup is deleted.
+type DrainHook interface {
+	// Drain is called after the new nodegroup becomes active. The old nodegroup
+	// isn't deleted if Drain returns an error.
+	Drain(ctx context.Context, oldNodegroup, newNodegroup *GetView) error
+}
+
+// DrainHookFunc is an adapter to use ordinary functions as a DrainHook.
+type DrainHookFunc func(ctx context.Context, oldNodegroup, newNodegroup *GetView) error
+
+// Drain calls f(ctx, oldNodegroup, newNodegroup).
+func (f DrainHookFunc) Drain(ctx context.Context, oldNodegroup, newNodegroup *GetView) error {
+	return f(ctx, oldNodegroup, newNodegroup)
+}
+
+// ReplaceOpts represents options for the Replace request.
+type ReplaceOpts struct {
+	// Modify changes options of the new nodegroup. The options are copied from the old
+	// nodegroup with GetView.CreateOpts before Modify is called.
+	Modify func(opts *CreateOpts)
+
+	// DrainHook is called after the new nodegroup becomes active. It can be nil.
+	DrainHook DrainHook
+
+	// PollInterval represents the interval between requests when waiting for nodegroups.
+	// task.DefaultPollInterval is used if it's not set.
+	PollInterval time.Duration
+
+	// MarkerLabel contains the key of a label that is set on the new nodegroup to the identifier
+	// of the old nodegroup. The label is used to find the new nodegroup when the creation
+	// response doesn't identify it and is kept after the replacement. No label is set if it's empty.
+	MarkerLabel string
+}
+
+// Replace replaces a cluster nodegroup with a new one. It can be used to change nodegroup
+// parameters that can't be changed by Update, such as the flavor, the volume or the
+// availability zone.
+//
+// The new nodegroup is created from the old nodegroup options changed by ReplaceOpts.Modify
+// with the ReplaceOpts.MarkerLabel label set if it's provided. After the new nodegroup becomes active the drain hook is
+// called and the old nodegroup deletion is requested. If the new nodegroup gets the error
+// status it's deleted and ReplaceRollbackError is returned. Other errors of waiting for
+// the new nodegroup, such as request failures or the context cancellation, don't delete it,
+// the new nodegroup is returned along with the error. If the drain hook fails both
+// nodegroups are kept.
+func Replace(ctx context.Context, client *v1.ServiceClient, clusterID, nodegroupID string,
+	opts *ReplaceOpts,
+) (*GetView, error) {
+	if opts == nil {
+		opts = &ReplaceOpts{}
+	}
+
+	oldNodegroup, _, err := Get(ctx, client, clusterID, nodegroupID)
+	if err != nil {
+		return nil, err
+	}
+	createOpts := oldNodegroup.CreateOpts()
+	if opts.Modify != nil {
+		opts.Modify(createOpts)
+	}
+	if opts.MarkerLabel != "" {
+		createOpts.Labels = (&LabelsPatch{Add: map[string]string{opts.MarkerLabel: oldNodegroup.ID}}).Apply(createOpts.Labels)
+	}
+
+	created, _, err := CreateAndGet(ctx, client, clusterID, createOpts,
+		&CreateAndGetOpts{PollInterval: opts.PollInterval})
+	if opts.MarkerLabel != "" &&
+		(errors.Is(err, ErrCreatedNodegroupNotFound) || errors.Is(err, ErrCreatedNodegroupAmbiguous)) {
+		created, err = findReplacement(ctx, client, clusterID, oldNodegroup.ID, opts.MarkerLabel, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	newNodegroup, err := WaitForActive(ctx, client, clusterID, created.ID, opts.PollInterval)
+	if errors.Is(err, ErrNodegroupError) {
+		return nil, rollbackReplace(client, clusterID, created.ID, err)
+	}
+	if err != nil {
+		return created, fmt.Errorf("wait for nodegroup %s: %w", created.ID, err)
+	}
+
+	if opts.DrainHook != nil {
+		if err := opts.DrainHook.Drain(ctx, oldNodegroup, newNodegroup); err != nil {
+			return newNodegroup, fmt.Errorf("drain nodegroup %s: %w", oldNodegroup.ID, err)
+		}
+	}
+
+	if _, err := Delete(ctx, client, clusterID, oldNodegroup.ID); err != nil {
+		return newNodegroup, fmt.Errorf("delete nodegroup %s: %w", oldNodegroup.ID, err)
+	}
+
+	return newNodegroup, nil
+}
+
+// findReplacement finds the new nodegroup by the marker label when the creation
+// response and the nodegroups list diff can't identify it. The discovery error is returned
+// if there isn't exactly one such nodegroup.
+func findReplacement(ctx context.Context, client *v1.ServiceClient, clusterID, oldNodegroupID, markerLabel string,
+	discoveryErr error,
+) (*GetView, error) {
+	nodegroups, _, err := List(ctx, client, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	var found []string
+	for _, ng := range nodegroups {
+		if ng.Labels[markerLabel] == oldNodegroupID && ng.ID != oldNodegroupID {
+			found = append(found, ng.ID)
+		}
+	}
+	if len(found) != 1 {
+		return nil, fmt.Errorf("find nodegroup with %s=%s label among %v: %w",
+			markerLabel, oldNodegroupID, found, discoveryErr)
+	}
+
+	mksNodegroup, _, err := Get(ctx, client, clusterID, found[0])
+
+	return mksNodegroup, err
+}
+
+// rollbackReplace deletes the new nodegroup that has failed to become active.
+// The deletion uses a new context limited by ReplaceRollbackTimeout.
+func rollbackReplace(client *v1.ServiceClient, clusterID, newNodegroupID string, reason error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), ReplaceRollbackTimeout)
+	defer cancel()
+
+	_, err := Delete(ctx, client, clusterID, newNodegroupID)
+
+	return &ReplaceRollbackError{
+		NodegroupID: newNodegroupID,
+		Reason:      reason,
+		DeleteErr:   err,
+	}
+}
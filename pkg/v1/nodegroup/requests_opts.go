@@ -89,3 +89,43 @@ type UpdateOpts struct {
 	// AutoscaleMaxNodes represents maximum possible number of worker nodes in the nodegroup.
 	AutoscaleMaxNodes *int `json:"autoscale_max_nodes,omitempty"`
 }
+
+// CreateOpts builds options for the nodegroup Create request that can be used
+// to create a nodegroup with the same parameters as the current one.
+// Nodes count is taken from the current amount of nodes in the nodegroup.
+func (result *GetView) CreateOpts() *CreateOpts {
+	opts := &CreateOpts{
+		Count:            len(result.Nodes),
+		FlavorID:         result.FlavorID,
+		VolumeGB:         result.VolumeGB,
+		VolumeType:       result.VolumeType,
+		LocalVolume:      result.LocalVolume,
+		AvailabilityZone: result.AvailabilityZone,
+		Labels:           make(map[string]string, len(result.Labels)),
+		Taints:           make([]Taint, len(result.Taints)),
+		UserData:         result.UserData,
+	}
+	for k, v := range result.Labels {
+		opts.Labels[k] = v
+	}
+	copy(opts.Taints, result.Taints)
+
+	if result.EnableAutoscale {
+		enableAutoscale := true
+		minNodes := result.AutoscaleMinNodes
+		maxNodes := result.AutoscaleMaxNodes
+		opts.EnableAutoscale = &enableAutoscale
+		opts.AutoscaleMinNodes = &minNodes
+		opts.AutoscaleMaxNodes = &maxNodes
+	}
+	if result.InstallNvidiaDevicePlugin {
+		installNvidiaDevicePlugin := true
+		opts.InstallNvidiaDevicePlugin = &installNvidiaDevicePlugin
+	}
+	if result.Preemptible {
+		preemptible := true
+		opts.Preemptible = &preemptible
+	}
+
+	return opts
+}
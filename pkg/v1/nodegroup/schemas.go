@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"time"
 
+	v1 "github.com/selectel/mks-go/pkg/v1"
 	"github.com/selectel/mks-go/pkg/v1/node"
 )
 
@@ -45,6 +46,17 @@ func isStatusSupported(s Status) bool {
 	return false
 }
 
+// parseStatus returns the status if it's supported or StatusUnknown otherwise.
+// Unsupported statuses are reported with v1.ReportUnknownValue.
+func parseStatus(raw string) Status {
+	if isStatusSupported(Status(raw)) {
+		return Status(raw)
+	}
+	v1.ReportUnknownValue(v1.UnknownValueNodegroupStatus, raw)
+
+	return StatusUnknown
+}
+
 // BaseView represents a base struct of unmarshalled nodegroup body from an API response.
 //
 //nolint:maligned
@@ -61,6 +73,10 @@ type BaseView struct {
 	// Status represents the current status of the nodegroup.
 	Status Status `json:"-"`
 
+	// RawStatus contains the status as it was returned by the API.
+	// It's kept even if Status is StatusUnknown.
+	RawStatus string `json:"-"`
+
 	// ClusterID contains cluster identifier.
 	ClusterID string `json:"cluster_id"`
 
@@ -123,13 +139,36 @@ type GetView struct {
 
 	// UserData represents base64 data which is used to pass a script that worker nodes run on boot.
 	UserData string `json:"user_data"`
+
+	// Extra contains fields of the API response that are not modelled by the client.
+	// They are kept to be marshalled back.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (result *ListView) UnmarshalJSON(b []byte) error {
+	type tmp ListView
+	var s struct {
+		tmp
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	*result = ListView(s.tmp)
+
+	// Check nodegroup status.
+	result.Status = parseStatus(s.Status)
+	result.RawStatus = s.Status
+
+	return nil
 }
 
 func (result *GetView) UnmarshalJSON(b []byte) error {
 	type tmp GetView
 	var s struct {
 		tmp
-		Status Status `json:"status"`
+		Status string `json:"status"`
 	}
 	if err := json.Unmarshal(b, &s); err != nil {
 		return err
@@ -138,15 +177,35 @@ func (result *GetView) UnmarshalJSON(b []byte) error {
 	*result = GetView(s.tmp)
 
 	// Check nodegroup status.
-	if isStatusSupported(s.Status) {
-		result.Status = s.Status
-	} else {
-		result.Status = StatusUnknown
+	result.Status = parseStatus(s.Status)
+	result.RawStatus = s.Status
+
+	extra, err := v1.ExtractExtraFields(b, result, "status")
+	if err != nil {
+		return err
 	}
+	result.Extra = extra
 
 	return nil
 }
 
+// MarshalJSON marshals the nodegroup with its raw status and extra fields.
+func (result GetView) MarshalJSON() ([]byte, error) {
+	type tmp GetView
+	s := struct {
+		tmp
+		Status string `json:"status"`
+	}{
+		tmp:    tmp(result),
+		Status: result.RawStatus,
+	}
+	if s.Status == "" {
+		s.Status = string(result.Status)
+	}
+
+	return v1.MarshalWithExtraFields(s, result.Extra)
+}
+
 // TaintEffect represents an effect of the node's taint.
 type TaintEffect string
 
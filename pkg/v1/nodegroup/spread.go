@@ -0,0 +1,225 @@
+package nodegroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	v1 "github.com/selectel/mks-go/pkg/v1"
+)
+
+// SpreadZoneLabel represents the well-known Kubernetes zone label that is set on nodegroups
+// created by PlanSpread. It contains the availability zone of the nodegroup and is used
+// by Rebalance to find the spread nodegroups.
+const SpreadZoneLabel = "topology.kubernetes.io/zone"
+
+// ErrInvalidSpread is returned when nodes can't be spread across availability zones.
+var ErrInvalidSpread = errors.New("invalid zone spread")
+
+// PlanSpread returns options for the nodegroup Create request for every availability zone.
+// Nodes are spread evenly, the first zones get an extra node if the total count can't be
+// divided evenly. Options are copied from the template with the zone, the nodes count and
+// the SpreadZoneLabel label set. The volume type suffix is replaced with the zone if the
+// template volume type is zonal, for example "fast.ru-3a".
+func PlanSpread(total int, zones []string, template *CreateOpts) ([]*CreateOpts, error) {
+	if len(zones) == 0 {
+		return nil, fmt.Errorf("%w: no zones", ErrInvalidSpread)
+	}
+	if total < len(zones) {
+		return nil, fmt.Errorf("%w: %d nodes can't be spread across %d zones", ErrInvalidSpread, total, len(zones))
+	}
+	seen := make(map[string]struct{}, len(zones))
+	for _, zone := range zones {
+		if _, ok := seen[zone]; ok {
+			return nil, fmt.Errorf("%w: duplicate zone %s", ErrInvalidSpread, zone)
+		}
+		seen[zone] = struct{}{}
+	}
+
+	counts := balancedCounts(total, len(zones))
+	plan := make([]*CreateOpts, 0, len(zones))
+	for i, zone := range zones {
+		plan = append(plan, zonalCreateOpts(template, zone, counts[i]))
+	}
+
+	return plan, nil
+}
+
+// zonalCreateOpts returns a copy of the template for the zone.
+func zonalCreateOpts(template *CreateOpts, zone string, count int) *CreateOpts {
+	opts := *template
+	opts.Count = count
+	opts.AvailabilityZone = zone
+	if template.AvailabilityZone != "" && strings.HasSuffix(template.VolumeType, "."+template.AvailabilityZone) {
+		opts.VolumeType = strings.TrimSuffix(template.VolumeType, template.AvailabilityZone) + zone
+	}
+	opts.Labels = make(map[string]string, len(template.Labels)+1)
+	for k, v := range template.Labels {
+		opts.Labels[k] = v
+	}
+	opts.Labels[SpreadZoneLabel] = zone
+	opts.Taints = append([]Taint(nil), template.Taints...)
+
+	return &opts
+}
+
+// balancedCounts splits the total into n counts that differ by one at most.
+func balancedCounts(total, n int) []int {
+	counts := make([]int, n)
+	for i := range counts {
+		counts[i] = total / n
+		if i < total%n {
+			counts[i]++
+		}
+	}
+
+	return counts
+}
+
+// ResizeCall represents a nodegroup Resize request that restores the zone balance.
+type ResizeCall struct {
+	// NodegroupID contains the nodegroup identifier.
+	NodegroupID string
+
+	// AvailabilityZone represents the zone of the nodegroup.
+	AvailabilityZone string
+
+	// Current represents the current amount of nodes in the nodegroup.
+	Current int
+
+	// Opts contains options for the Resize request.
+	Opts *ResizeOpts
+}
+
+// PlanRebalance returns Resize requests that spread nodes evenly across the nodegroups
+// of different zones. The current total amount of nodes is kept if total isn't positive.
+// Zones that have more nodes keep the extra nodes of an uneven split. Scale ups are placed
+// before scale downs, so the capacity isn't reduced during the rebalance.
+func PlanRebalance(nodegroups []*ListView, total int) ([]ResizeCall, error) {
+	if len(nodegroups) == 0 {
+		return nil, fmt.Errorf("%w: no nodegroups", ErrInvalidSpread)
+	}
+
+	current, err := spreadTotal(nodegroups)
+	if err != nil {
+		return nil, err
+	}
+	if total <= 0 {
+		total = current
+	}
+	if total < len(nodegroups) {
+		return nil, fmt.Errorf("%w: %d nodes can't be spread across %d zones", ErrInvalidSpread, total, len(nodegroups))
+	}
+
+	sorted := append([]*ListView(nil), nodegroups...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if len(sorted[i].Nodes) != len(sorted[j].Nodes) {
+			return len(sorted[i].Nodes) > len(sorted[j].Nodes)
+		}
+
+		return sorted[i].AvailabilityZone < sorted[j].AvailabilityZone
+	})
+
+	var scaleUps, scaleDowns []ResizeCall
+	for i, desired := range balancedCounts(total, len(sorted)) {
+		ng := sorted[i]
+		call := ResizeCall{
+			NodegroupID:      ng.ID,
+			AvailabilityZone: ng.AvailabilityZone,
+			Current:          len(ng.Nodes),
+			Opts:             &ResizeOpts{Desired: desired},
+		}
+		switch {
+		case desired > call.Current:
+			scaleUps = append(scaleUps, call)
+		case desired < call.Current:
+			scaleDowns = append(scaleDowns, call)
+		}
+	}
+
+	return append(scaleUps, scaleDowns...), nil
+}
+
+// spreadTotal returns the total amount of nodes in the nodegroups and checks
+// that every nodegroup is placed in its own zone.
+func spreadTotal(nodegroups []*ListView) (int, error) {
+	zones := make(map[string]struct{}, len(nodegroups))
+	total := 0
+	for _, ng := range nodegroups {
+		if _, ok := zones[ng.AvailabilityZone]; ok {
+			return 0, fmt.Errorf("%w: several nodegroups in zone %s", ErrInvalidSpread, ng.AvailabilityZone)
+		}
+		zones[ng.AvailabilityZone] = struct{}{}
+		total += len(ng.Nodes)
+	}
+
+	return total, nil
+}
+
+// RebalanceOpts represents options for the Rebalance request.
+type RebalanceOpts struct {
+	// Total represents the amount of nodes to spread. The current total amount of nodes
+	// is kept if it isn't positive.
+	Total int
+
+	// PollInterval represents the interval between requests when waiting for resizes.
+	// task.DefaultPollInterval is used if it's not set.
+	PollInterval time.Duration
+}
+
+// Rebalance spreads nodes evenly across the cluster nodegroups that have the SpreadZoneLabel
+// label. Resize requests are sent one by one: every request waits for its resize task and
+// for the nodegroup to become active before the next one is sent, since the cluster doesn't
+// accept a resize while another one is in progress. The finished calls are returned along
+// with an error.
+func Rebalance(ctx context.Context, client *v1.ServiceClient, clusterID string,
+	opts *RebalanceOpts,
+) ([]ResizeCall, error) {
+	if opts == nil {
+		opts = &RebalanceOpts{}
+	}
+
+	nodegroups, _, err := List(ctx, client, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	var spread []*ListView
+	for _, ng := range nodegroups {
+		if _, ok := ng.Labels[SpreadZoneLabel]; ok {
+			spread = append(spread, ng)
+		}
+	}
+	calls, err := PlanRebalance(spread, opts.Total)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, call := range calls {
+		if err := resizeAndWait(ctx, client, clusterID, call, opts.PollInterval); err != nil {
+			return calls[:i], fmt.Errorf("resize nodegroup %s: %w", call.NodegroupID, err)
+		}
+	}
+
+	return calls, nil
+}
+
+// resizeAndWait sends the resize request and waits until it's finished.
+func resizeAndWait(ctx context.Context, client *v1.ServiceClient, clusterID string, call ResizeCall,
+	interval time.Duration,
+) error {
+	op, _, err := ResizeAndTrack(ctx, client, clusterID, call.NodegroupID, call.Opts)
+	if err != nil {
+		return err
+	}
+	op.PollInterval = interval
+	if _, err := op.Wait(ctx); err != nil {
+		return err
+	}
+	_, err = WaitForActive(ctx, client, clusterID, call.NodegroupID, interval)
+
+	return err
+}
@@ -0,0 +1,62 @@
+package nodegroup
+
+// Operation represents custom type for nodegroup operations that depend on the nodegroup status.
+type Operation string
+
+const (
+	OperationUpdate Operation = "UPDATE"
+	OperationResize Operation = "RESIZE"
+	OperationDelete Operation = "DELETE"
+)
+
+// allowedOperations contains operations that the API allows in each known status.
+var allowedOperations = map[Status][]Operation{
+	StatusActive: {
+		OperationUpdate,
+		OperationResize,
+		OperationDelete,
+	},
+	StatusPendingCreate:        {OperationDelete},
+	StatusPendingUpdate:        {OperationDelete},
+	StatusPendingDelete:        {},
+	StatusPendingScaleUp:       {OperationDelete},
+	StatusPendingScaleDown:     {OperationDelete},
+	StatusPendingNodeReinstall: {OperationDelete},
+	StatusError:                {OperationDelete},
+}
+
+// IsStable returns true if the nodegroup is ready to accept any operation.
+func (s Status) IsStable() bool {
+	return s == StatusActive
+}
+
+// IsError returns true if the nodegroup has failed.
+func (s Status) IsError() bool {
+	return s == StatusError
+}
+
+// IsPending returns true if the API is changing the nodegroup.
+func (s Status) IsPending() bool {
+	return isStatusSupported(s) && !s.IsStable() && !s.IsError()
+}
+
+// IsTerminal returns true if the API won't change the status without a new request.
+func (s Status) IsTerminal() bool {
+	return s.IsStable() || s.IsError()
+}
+
+// CanPerform returns true if the API allows the operation for a nodegroup with the status.
+// It returns true for unknown statuses so the API could make the decision.
+func (s Status) CanPerform(operation Operation) bool {
+	operations, ok := allowedOperations[s]
+	if !ok {
+		return true
+	}
+	for _, allowed := range operations {
+		if allowed == operation {
+			return true
+		}
+	}
+
+	return false
+}
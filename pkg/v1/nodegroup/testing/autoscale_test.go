@@ -0,0 +1,144 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/selectel/mks-go/pkg/testutils"
+	"github.com/selectel/mks-go/pkg/v1/nodegroup"
+)
+
+func TestAutoscalePolicyValidate(t *testing.T) {
+	testCases := []struct {
+		policy nodegroup.AutoscalePolicy
+		count  int
+		valid  bool
+	}{
+		{policy: nodegroup.AutoscalePolicy{}, count: 10, valid: true},
+		{policy: nodegroup.AutoscalePolicy{Enabled: true, MinNodes: 1, MaxNodes: 5}, count: 3, valid: true},
+		{policy: nodegroup.AutoscalePolicy{Enabled: true, MinNodes: 1, MaxNodes: 5}, count: 6, valid: false},
+		{policy: nodegroup.AutoscalePolicy{Enabled: true, MinNodes: 5, MaxNodes: 1}, count: 3, valid: false},
+		{policy: nodegroup.AutoscalePolicy{Enabled: true, MinNodes: -1, MaxNodes: 1}, count: 0, valid: false},
+		{policy: nodegroup.AutoscalePolicy{Enabled: true}, count: 0, valid: false},
+	}
+
+	for _, testCase := range testCases {
+		err := testCase.policy.Validate(testCase.count)
+		if testCase.valid && err != nil {
+			t.Fatalf("expected %+v to be valid for %d nodes, but got %v", testCase.policy, testCase.count, err)
+		}
+		if !testCase.valid && !errors.Is(err, nodegroup.ErrInvalidAutoscalePolicy) {
+			t.Fatalf("expected ErrInvalidAutoscalePolicy for %+v and %d nodes, but got %v",
+				testCase.policy, testCase.count, err)
+		}
+	}
+}
+
+func TestAutoscalePolicyOpts(t *testing.T) {
+	policy := nodegroup.AutoscalePolicy{Enabled: true, MinNodes: 2, MaxNodes: 4}
+
+	createOpts := &nodegroup.CreateOpts{}
+	policy.ApplyToCreateOpts(createOpts)
+	if actual := createOpts.AutoscalePolicy(); actual != policy {
+		t.Fatalf("expected %+v policy, but got %+v", policy, actual)
+	}
+
+	updateOpts := &nodegroup.UpdateOpts{}
+	policy.ApplyToUpdateOpts(updateOpts)
+	if actual := updateOpts.AutoscalePolicy(); actual != policy {
+		t.Fatalf("expected %+v policy, but got %+v", policy, actual)
+	}
+
+	nodegroup.AutoscalePolicy{}.ApplyToUpdateOpts(updateOpts)
+	if *updateOpts.EnableAutoscale || updateOpts.AutoscaleMinNodes != nil || updateOpts.AutoscaleMaxNodes != nil {
+		t.Fatalf("expected autoscale to be disabled without bounds, but got %+v", updateOpts)
+	}
+}
+
+func TestEnableDisableAutoscale(t *testing.T) {
+	opts := &nodegroup.CreateOpts{Count: 1}
+	if err := nodegroup.EnableAutoscale(opts, 2, 5); err != nil {
+		t.Fatal(err)
+	}
+	expected := nodegroup.AutoscalePolicy{Enabled: true, MinNodes: 2, MaxNodes: 5}
+	if opts.Count != 2 || !reflect.DeepEqual(expected, opts.AutoscalePolicy()) {
+		t.Fatalf("expected 2 nodes with %+v policy, but got %d nodes with %+v policy",
+			expected, opts.Count, opts.AutoscalePolicy())
+	}
+
+	if err := nodegroup.EnableAutoscale(opts, 5, 2); !errors.Is(err, nodegroup.ErrInvalidAutoscalePolicy) {
+		t.Fatalf("expected ErrInvalidAutoscalePolicy, but got %v", err)
+	}
+
+	opts = &nodegroup.CreateOpts{}
+	if err := nodegroup.EnableAutoscale(opts, 3, 5); err != nil {
+		t.Fatal(err)
+	}
+	opts.Count = 0
+	nodegroup.DisableAutoscale(opts)
+	if opts.Count != 3 || opts.AutoscalePolicy().Enabled || opts.AutoscaleMinNodes != nil {
+		t.Fatalf("expected 3 nodes with disabled autoscale, but got %+v", opts)
+	}
+}
+
+func TestResizeAutoscaledNodegroup(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	autoscaledResponse := strings.NewReplacer(
+		`"enable_autoscale": false`, `"enable_autoscale": true`,
+		`"autoscale_min_nodes": 0`, `"autoscale_min_nodes": 1`,
+		`"autoscale_max_nodes": 0`, `"autoscale_max_nodes": 3`,
+	).Replace(testGetNodegroupResponseRaw)
+	getCalled := false
+	testutils.HandleReqWithoutBody(t, &testutils.HandleReqOpts{
+		Mux:         testEnv.Mux,
+		URL:         fmt.Sprintf("/v1/clusters/%s/nodegroups/%s", clusterID, nodegroupID),
+		RawResponse: autoscaledResponse,
+		Method:      http.MethodGet,
+		Status:      http.StatusOK,
+		CallFlag:    &getCalled,
+	})
+	resizeCalls := 0
+	testEnv.Mux.HandleFunc(fmt.Sprintf("/v1/clusters/%s/nodegroups/%s/resize", clusterID, nodegroupID),
+		func(w http.ResponseWriter, r *http.Request) {
+			resizeCalls++
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+	ctx := context.Background()
+	testClient := newCreateTestClient(testEnv)
+
+	responseResult, err := nodegroup.ResizeChecked(ctx, testClient, clusterID, nodegroupID, &nodegroup.ResizeOpts{Desired: 5})
+	if !errors.Is(err, nodegroup.ErrResizeOutOfAutoscaleBounds) {
+		t.Fatalf("expected ErrResizeOutOfAutoscaleBounds, but got %v", err)
+	}
+	if responseResult == nil || responseResult.StatusCode != http.StatusOK {
+		t.Fatalf("expected the result of the nodegroup request, but got %#v", responseResult)
+	}
+	if resizeCalls != 0 {
+		t.Fatal("expected the resize endpoint not to be called")
+	}
+
+	if _, err := nodegroup.ResizeChecked(ctx, testClient, clusterID, nodegroupID, &nodegroup.ResizeOpts{Desired: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := nodegroup.Resize(ctx, testClient, clusterID, nodegroupID, &nodegroup.ResizeOpts{Desired: 5}); err != nil {
+		t.Fatal(err)
+	}
+	if resizeCalls != 2 {
+		t.Fatalf("expected 2 resize requests, but got %d", resizeCalls)
+	}
+
+	if _, err := nodegroup.ResizeChecked(ctx, testClient, clusterID, nodegroupID, nil); err != nil {
+		t.Fatal(err)
+	}
+	if resizeCalls != 3 {
+		t.Fatalf("expected 3 resize requests, but got %d", resizeCalls)
+	}
+}
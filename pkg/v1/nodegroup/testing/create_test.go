@@ -0,0 +1,249 @@
+package testing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/selectel/mks-go/pkg/testutils"
+	v1 "github.com/selectel/mks-go/pkg/v1"
+	"github.com/selectel/mks-go/pkg/v1/nodegroup"
+)
+
+const createdNodegroupID = "a3ba6a4b-1c61-45ac-b1d2-9d6b2c3e1f7a"
+
+// fakeNodegroups emulates nodegroups endpoints of a single cluster.
+type fakeNodegroups struct {
+	mu       sync.Mutex
+	statuses map[string]string
+	order    []string
+
+	// respond configures the creation response.
+	respond func(w http.ResponseWriter)
+
+	// hideCreated hides the created nodegroup from the list.
+	hideCreated bool
+
+	// failCreated makes the created nodegroup have the error status.
+	failCreated bool
+
+	// failGetCreated makes requests of the created nodegroup after the first one fail
+	// with the internal error.
+	failGetCreated bool
+
+	// concurrentID contains the identifier of a nodegroup that is created along with
+	// the requested one by someone else.
+	concurrentID string
+
+	// getCalls counts requests of the created nodegroup.
+	getCalls int
+
+	// createOpts contains options of the creation request.
+	createOpts *nodegroup.CreateOpts
+
+	// deleted contains identifiers of the deleted nodegroups.
+	deleted []string
+}
+
+func handleFakeNodegroups(mux *http.ServeMux, respond func(w http.ResponseWriter)) *fakeNodegroups {
+	fake := &fakeNodegroups{
+		statuses: map[string]string{nodegroupID: string(nodegroup.StatusActive)},
+		order:    []string{nodegroupID},
+		respond:  respond,
+	}
+	baseURL := fmt.Sprintf("/v1/clusters/%s/nodegroups", clusterID)
+	mux.HandleFunc(baseURL, func(w http.ResponseWriter, r *http.Request) {
+		fake.mu.Lock()
+		defer fake.mu.Unlock()
+
+		if r.Method == http.MethodPost {
+			var body struct {
+				Nodegroup *nodegroup.CreateOpts `json:"nodegroup"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			fake.createOpts = body.Nodegroup
+			fake.statuses[createdNodegroupID] = string(nodegroup.StatusPendingCreate)
+			if !fake.hideCreated {
+				fake.order = append(fake.order, createdNodegroupID)
+			}
+			if fake.concurrentID != "" {
+				fake.statuses[fake.concurrentID] = string(nodegroup.StatusPendingCreate)
+				fake.order = append(fake.order, fake.concurrentID)
+			}
+			fake.respond(w)
+
+			return
+		}
+		nodegroups := make([]map[string]interface{}, 0, len(fake.order))
+		for _, id := range fake.order {
+			nodegroups = append(nodegroups, map[string]interface{}{
+				"id": id, "status": fake.statuses[id], "labels": fake.labels(id),
+			})
+		}
+		writeNodegroupsJSON(w, map[string]interface{}{"nodegroups": nodegroups})
+	})
+	mux.HandleFunc(baseURL+"/", func(w http.ResponseWriter, r *http.Request) {
+		fake.mu.Lock()
+		defer fake.mu.Unlock()
+
+		id := strings.TrimPrefix(r.URL.Path, baseURL+"/")
+		status, ok := fake.statuses[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+		if r.Method == http.MethodDelete {
+			fake.deleted = append(fake.deleted, id)
+			w.WriteHeader(http.StatusNoContent)
+
+			return
+		}
+		if id == createdNodegroupID && fake.failGetCreated && fake.getCalls > 0 {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+		if id == createdNodegroupID {
+			fake.getCalls++
+			// The created nodegroup becomes active on the second request.
+			if fake.getCalls > 1 && status == string(nodegroup.StatusPendingCreate) {
+				status = string(nodegroup.StatusActive)
+				if fake.failCreated {
+					status = string(nodegroup.StatusError)
+				}
+				fake.statuses[id] = status
+			}
+		}
+		writeNodegroupsJSON(w, map[string]interface{}{
+			"nodegroup": map[string]interface{}{
+				"id":                id,
+				"cluster_id":        clusterID,
+				"status":            status,
+				"flavor_id":         "old-flavor",
+				"availability_zone": "ru-3a",
+				"labels":            fake.labels(id),
+			},
+		})
+	})
+
+	return fake
+}
+
+// labels returns labels of the nodegroup referenced by its id.
+func (fake *fakeNodegroups) labels(id string) map[string]string {
+	if id == createdNodegroupID && fake.createOpts != nil {
+		return fake.createOpts.Labels
+	}
+
+	return map[string]string{"app": "web"}
+}
+
+func writeNodegroupsJSON(w http.ResponseWriter, body interface{}) {
+	w.Header().Add("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func newCreateTestClient(testEnv *testutils.TestEnv) *v1.ServiceClient {
+	return &v1.ServiceClient{
+		HTTPClient: &http.Client{},
+		TokenID:    testutils.TokenID,
+		Endpoint:   testEnv.Server.URL + "/v1",
+		UserAgent:  testutils.UserAgent,
+	}
+}
+
+func TestCreateAndGet(t *testing.T) {
+	testCases := map[string]func(w http.ResponseWriter){
+		"body": func(w http.ResponseWriter) {
+			writeNodegroupsJSON(w, map[string]interface{}{"nodegroup": map[string]string{"id": createdNodegroupID}})
+		},
+		"location": func(w http.ResponseWriter) {
+			w.Header().Set("Location", fmt.Sprintf("/v1/clusters/%s/nodegroups/%s", clusterID, createdNodegroupID))
+			w.WriteHeader(http.StatusNoContent)
+		},
+		"diff": func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusNoContent)
+		},
+	}
+
+	for name, respond := range testCases {
+		respond := respond
+		t.Run(name, func(t *testing.T) {
+			testEnv := testutils.SetupTestEnv()
+			defer testEnv.TearDownTestEnv()
+			handleFakeNodegroups(testEnv.Mux, respond)
+
+			actual, _, err := nodegroup.CreateAndGet(context.Background(), newCreateTestClient(testEnv), clusterID,
+				testCreateNodegroupOpts, &nodegroup.CreateAndGetOpts{PollInterval: time.Millisecond})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actual.ID != createdNodegroupID || actual.Status != nodegroup.StatusPendingCreate {
+				t.Fatalf("expected pending %s nodegroup, but got %#v", createdNodegroupID, actual)
+			}
+		})
+	}
+}
+
+func TestCreateAndGetWaitForActive(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	fake := handleFakeNodegroups(testEnv.Mux, func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	actual, _, err := nodegroup.CreateAndGet(context.Background(), newCreateTestClient(testEnv), clusterID,
+		testCreateNodegroupOpts, &nodegroup.CreateAndGetOpts{WaitForActive: true, PollInterval: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual.ID != createdNodegroupID || actual.Status != nodegroup.StatusActive {
+		t.Fatalf("expected active %s nodegroup, but got %#v", createdNodegroupID, actual)
+	}
+	if fake.getCalls != 2 {
+		t.Fatalf("expected 2 requests of the created nodegroup, but got %d", fake.getCalls)
+	}
+}
+
+func TestCreateAndGetNotFound(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	fake := handleFakeNodegroups(testEnv.Mux, func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	fake.hideCreated = true
+
+	_, _, err := nodegroup.CreateAndGet(context.Background(), newCreateTestClient(testEnv), clusterID,
+		testCreateNodegroupOpts, &nodegroup.CreateAndGetOpts{PollInterval: time.Millisecond, DiscoveryAttempts: 2})
+	if !errors.Is(err, nodegroup.ErrCreatedNodegroupNotFound) {
+		t.Fatalf("expected ErrCreatedNodegroupNotFound, but got %v", err)
+	}
+}
+
+func TestCreateAndGetHTTPError(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	handleFakeNodegroups(testEnv.Mux, func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte(testErrGenericResponseRaw))
+	})
+
+	actual, httpResponse, err := nodegroup.CreateAndGet(context.Background(), newCreateTestClient(testEnv), clusterID,
+		testCreateNodegroupOpts, nil)
+	if err == nil {
+		t.Fatal("expected error from the CreateAndGet method")
+	}
+	if actual != nil {
+		t.Fatalf("expected no nodegroup, but got %#v", actual)
+	}
+	if httpResponse == nil || httpResponse.StatusCode != http.StatusBadGateway {
+		t.Fatalf("expected %d status in the HTTP response, but got %v", http.StatusBadGateway, httpResponse)
+	}
+}
@@ -0,0 +1,37 @@
+package testing
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/selectel/mks-go/pkg/v1/nodegroup"
+)
+
+func TestNodegroupExtraFieldsRoundTrip(t *testing.T) {
+	var actual nodegroup.GetView
+	if err := json.Unmarshal([]byte(testNodegroupExtraFieldsRaw), &actual); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedExtra := map[string]json.RawMessage{"placement_group": json.RawMessage(`"pg-1"`)}
+	if !reflect.DeepEqual(expectedExtra, actual.Extra) {
+		t.Fatalf("expected %s nodegroup extra fields, but got %s", expectedExtra, actual.Extra)
+	}
+	expectedNodeExtra := map[string]json.RawMessage{"gpu_count": json.RawMessage(`2`)}
+	if len(actual.Nodes) != 1 || !reflect.DeepEqual(expectedNodeExtra, actual.Nodes[0].Extra) {
+		t.Fatalf("expected a node with %s extra fields, but got %#v", expectedNodeExtra, actual.Nodes)
+	}
+
+	marshalled, err := json.Marshal(&actual)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var roundTrip nodegroup.GetView
+	if err := json.Unmarshal(marshalled, &roundTrip); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(actual, roundTrip) {
+		t.Fatalf("expected %#v, but got %#v", actual, roundTrip)
+	}
+}
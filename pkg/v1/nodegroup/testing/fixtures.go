@@ -66,6 +66,7 @@ var expectedGetNodegroupResponse = &nodegroup.GetView{
 		FlavorID:         "99b62670-9d78-43fd-8f55-d184a4800f8d",
 		VolumeGB:         10,
 		Status:           nodegroup.StatusActive,
+		RawStatus:        "ACTIVE",
 		VolumeType:       "basic.ru-1a",
 		LocalVolume:      false,
 		AvailabilityZone: "ru-1a",
@@ -111,6 +112,7 @@ const testListNodegroupsResponseRaw = `
             "flavor_id": "99b62670-9d78-43fd-8f55-d184a4800f8d",
             "id": "a376745a-fbcb-413d-b418-169d059d79ce",
             "local_volume": false,
+            "status": "ACTIVE",
             "nodes": [
                 {
                     "created_at": "2020-02-19T15:41:45.948646Z",
@@ -162,6 +164,8 @@ var expectedListNodegroupsResponse = []*nodegroup.ListView{
 			VolumeType:       "basic.ru-1a",
 			LocalVolume:      false,
 			AvailabilityZone: "ru-1a",
+			Status:           nodegroup.StatusActive,
+			RawStatus:        "ACTIVE",
 			Nodes: []*node.View{
 				{
 					ID:          "39e5dd4d-5e23-4a00-8173-974bf844f21b",
@@ -335,3 +339,33 @@ const testSingleNodegroupInvalidResponseRaw = `
 
 // testErrGenericResponseRaw represents a raw response with an error in the generic format.
 const testErrGenericResponseRaw = `{"error":{"message":"bad gateway"}}`
+
+// testListNodegroupsUnknownStatusResponseRaw represents a raw response from the List method
+// with a nodegroup status that is unknown to the client.
+const testListNodegroupsUnknownStatusResponseRaw = `
+{
+    "nodegroups": [
+        {
+            "cluster_id": "79265515-3700-49fa-af0e-7f547bce788a",
+            "id": "a376745a-fbcb-413d-b418-169d059d79ce",
+            "status": "PENDING_MIGRATE"
+        }
+    ]
+}
+`
+
+// testNodegroupExtraFieldsRaw represents a raw nodegroup with fields that are not modelled by the client.
+const testNodegroupExtraFieldsRaw = `
+{
+    "id": "a376745a-fbcb-413d-b418-169d059d79ce",
+    "status": "PENDING_SCALE_UP",
+    "placement_group": "pg-1",
+    "nodes": [
+        {
+            "id": "39e5dd4d-5e23-4a00-8173-974bf844f21b",
+            "hostname": "test-cluster-node-eegp9",
+            "gpu_count": 2
+        }
+    ]
+}
+`
@@ -0,0 +1,208 @@
+package testing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/selectel/mks-go/pkg/testutils"
+	"github.com/selectel/mks-go/pkg/v1/nodegroup"
+)
+
+func TestLabelsPatchApply(t *testing.T) {
+	labels := map[string]string{"app": "web", "tier": "frontend"}
+	patch := &nodegroup.LabelsPatch{
+		Remove: []string{"tier", "unknown"},
+		Add:    map[string]string{"app": "api", "team": "core"},
+	}
+
+	expected := map[string]string{"app": "api", "team": "core"}
+	if actual := patch.Apply(labels); !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected %v labels, but got %v", expected, actual)
+	}
+	if _, ok := labels["team"]; ok {
+		t.Fatal("expected the source labels not to be changed")
+	}
+
+	patch = &nodegroup.LabelsPatch{
+		Replace: map[string]string{"env": "prod"},
+		Add:     map[string]string{"team": "core"},
+	}
+	expected = map[string]string{"env": "prod", "team": "core"}
+	if actual := patch.Apply(labels); !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected %v labels, but got %v", expected, actual)
+	}
+}
+
+func TestTaintsPatchApply(t *testing.T) {
+	taints := []nodegroup.Taint{
+		{Key: "dedicated", Value: "gpu", Effect: nodegroup.NoScheduleEffect},
+		{Key: "dedicated", Value: "gpu", Effect: nodegroup.NoExecuteEffect},
+		{Key: "spot", Value: "true", Effect: nodegroup.PreferNoScheduleEffect},
+	}
+	patch := &nodegroup.TaintsPatch{
+		Remove: []nodegroup.Taint{{Key: "dedicated", Effect: nodegroup.NoExecuteEffect}},
+		Add: []nodegroup.Taint{
+			{Key: "dedicated", Value: "ml", Effect: nodegroup.NoScheduleEffect},
+			{Key: "zone", Value: "a", Effect: nodegroup.NoScheduleEffect},
+		},
+	}
+
+	expected := []nodegroup.Taint{
+		{Key: "dedicated", Value: "ml", Effect: nodegroup.NoScheduleEffect},
+		{Key: "spot", Value: "true", Effect: nodegroup.PreferNoScheduleEffect},
+		{Key: "zone", Value: "a", Effect: nodegroup.NoScheduleEffect},
+	}
+	if actual := patch.Apply(taints); !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected %v taints, but got %v", expected, actual)
+	}
+	if taints[0].Value != "gpu" {
+		t.Fatal("expected the source taints not to be changed")
+	}
+
+	patch = &nodegroup.TaintsPatch{Remove: []nodegroup.Taint{{Key: "dedicated"}}}
+	expected = []nodegroup.Taint{{Key: "spot", Value: "true", Effect: nodegroup.PreferNoScheduleEffect}}
+	if actual := patch.Apply(taints); !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected %v taints, but got %v", expected, actual)
+	}
+}
+
+// handlePatchNodegroup serves the nodegroup and records update requests.
+func handlePatchNodegroup(t *testing.T, testEnv *testutils.TestEnv) *[]*nodegroup.UpdateOpts {
+	var updates []*nodegroup.UpdateOpts
+	testEnv.Mux.HandleFunc(fmt.Sprintf("/v1/clusters/%s/nodegroups/%s", clusterID, nodegroupID),
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPut {
+				var body struct {
+					Nodegroup *nodegroup.UpdateOpts `json:"nodegroup"`
+				}
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Error(err)
+				}
+				updates = append(updates, body.Nodegroup)
+				w.WriteHeader(http.StatusNoContent)
+
+				return
+			}
+
+			w.Header().Add("Content-Type", "application/json")
+			_, _ = w.Write([]byte(testGetNodegroupResponseRaw))
+		})
+
+	return &updates
+}
+
+func TestPatchLabels(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	updates := handlePatchNodegroup(t, testEnv)
+
+	_, err := nodegroup.PatchLabels(context.Background(), newCreateTestClient(testEnv), clusterID, nodegroupID,
+		&nodegroup.LabelsPatch{Add: map[string]string{"team": "core"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*nodegroup.UpdateOpts{
+		{
+			Labels: map[string]string{"test-label-key": "test-label-value", "team": "core"},
+			Taints: []nodegroup.Taint{{Key: "test-key-0", Value: "test-value-0", Effect: nodegroup.NoScheduleEffect}},
+		},
+	}
+	if !reflect.DeepEqual(expected, *updates) {
+		t.Fatalf("expected %#v updates, but got %#v", expected, *updates)
+	}
+}
+
+func TestPatchTaints(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	updates := handlePatchNodegroup(t, testEnv)
+
+	_, err := nodegroup.PatchTaints(context.Background(), newCreateTestClient(testEnv), clusterID, nodegroupID,
+		&nodegroup.TaintsPatch{Remove: []nodegroup.Taint{{Key: "test-key-0"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*nodegroup.UpdateOpts{
+		{
+			Labels: map[string]string{"test-label-key": "test-label-value"},
+			Taints: []nodegroup.Taint{},
+		},
+	}
+	if !reflect.DeepEqual(expected, *updates) {
+		t.Fatalf("expected %#v updates, but got %#v", expected, *updates)
+	}
+}
+
+func TestPatchLabelsConcurrentModification(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	updates := handlePatchNodegroup(t, testEnv)
+
+	ctx := context.Background()
+	mksClient := newCreateTestClient(testEnv)
+	stale := time.Date(2020, 2, 19, 15, 0, 0, 0, time.UTC)
+	_, err := nodegroup.PatchLabels(ctx, mksClient, clusterID, nodegroupID,
+		&nodegroup.LabelsPatch{Add: map[string]string{"team": "core"}, ExpectedUpdatedAt: &stale})
+	if !errors.Is(err, nodegroup.ErrConcurrentModification) {
+		t.Fatalf("expected ErrConcurrentModification, but got %v", err)
+	}
+	if len(*updates) != 0 {
+		t.Fatalf("expected no updates, but got %#v", *updates)
+	}
+
+	current := time.Date(2020, 2, 19, 15, 41, 45, 948646000, time.UTC)
+	_, err = nodegroup.PatchTaints(ctx, mksClient, clusterID, nodegroupID,
+		&nodegroup.TaintsPatch{Remove: []nodegroup.Taint{{Key: "test-key-0"}}, ExpectedUpdatedAt: &current})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*updates) != 1 {
+		t.Fatalf("expected a single update, but got %#v", *updates)
+	}
+}
+
+func TestPatchLabelsModifiedBetweenReads(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	// The nodegroup is updated by someone else after the first read.
+	reads, updates := 0, 0
+	modified := strings.Replace(testGetNodegroupResponseRaw,
+		`"updated_at": "2020-02-19T15:41:45.948646Z",`, `"updated_at": "2020-02-19T16:00:00Z",`, 1)
+	testEnv.Mux.HandleFunc(fmt.Sprintf("/v1/clusters/%s/nodegroups/%s", clusterID, nodegroupID),
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPut {
+				updates++
+				w.WriteHeader(http.StatusNoContent)
+
+				return
+			}
+
+			reads++
+			w.Header().Add("Content-Type", "application/json")
+			if reads == 1 {
+				_, _ = w.Write([]byte(testGetNodegroupResponseRaw))
+
+				return
+			}
+			_, _ = w.Write([]byte(modified))
+		})
+
+	_, err := nodegroup.PatchLabels(context.Background(), newCreateTestClient(testEnv), clusterID, nodegroupID,
+		&nodegroup.LabelsPatch{Add: map[string]string{"team": "core"}})
+	if !errors.Is(err, nodegroup.ErrConcurrentModification) {
+		t.Fatalf("expected ErrConcurrentModification, but got %v", err)
+	}
+	if reads != 2 || updates != 0 {
+		t.Fatalf("expected 2 reads and no updates, but got %d reads and %d updates", reads, updates)
+	}
+}
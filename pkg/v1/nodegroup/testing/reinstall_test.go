@@ -0,0 +1,175 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/selectel/mks-go/pkg/testutils"
+	"github.com/selectel/mks-go/pkg/v1/nodegroup"
+	"github.com/selectel/mks-go/pkg/v1/task"
+)
+
+var rollingReinstallNodes = []string{"node-1", "node-2", "node-3", "node-4", "node-5"}
+
+// fakeReinstall emulates the nodegroup and node reinstall endpoints.
+type fakeReinstall struct {
+	mu          sync.Mutex
+	reinstalled []string
+	inFlight    int
+	maxInFlight int
+
+	// failNodes contains nodes whose reinstall tasks fail.
+	failNodes map[string]bool
+
+	// autorepair adds a never finishing reinstall task that has been started before the first request.
+	autorepair bool
+}
+
+func handleFakeReinstall(t *testing.T, testEnv *testutils.TestEnv) *fakeReinstall {
+	fake := &fakeReinstall{failNodes: map[string]bool{}}
+	fakeTasks := testutils.HandleFakeTasks(testEnv.Mux, clusterID)
+	nodegroupURL := fmt.Sprintf("/v1/clusters/%s/nodegroups/%s", clusterID, nodegroupID)
+
+	testEnv.Mux.HandleFunc(nodegroupURL, func(w http.ResponseWriter, r *http.Request) {
+		nodes := make([]map[string]string, 0, len(rollingReinstallNodes))
+		for _, nodeID := range rollingReinstallNodes {
+			nodes = append(nodes, map[string]string{"id": nodeID, "nodegroup_id": nodegroupID})
+		}
+		writeNodegroupsJSON(w, map[string]interface{}{
+			"nodegroup": map[string]interface{}{"id": nodegroupID, "status": "ACTIVE", "nodes": nodes},
+		})
+	})
+	testEnv.Mux.HandleFunc(nodegroupURL+"/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/reinstall") {
+			t.Errorf("unexpected %s request to %s", r.Method, r.URL.Path)
+		}
+		nodeID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, nodegroupURL+"/"), "/reinstall")
+
+		fake.mu.Lock()
+		defer fake.mu.Unlock()
+		fake.reinstalled = append(fake.reinstalled, nodeID)
+		fake.inFlight++
+		if fake.inFlight > fake.maxInFlight {
+			fake.maxInFlight = fake.inFlight
+		}
+
+		if fake.autorepair && len(fake.reinstalled) == 1 {
+			autorepair := fakeTasks.Add("autorepair", string(task.TypeNodeReinstall), string(task.StatusInProgress), nodegroupID)
+			startedAt := autorepair.StartedAt.Add(-time.Hour)
+			autorepair.StartedAt = &startedAt
+		}
+		taskID := "reinstall-" + nodeID + "-" + fmt.Sprint(len(fake.reinstalled))
+		fakeTasks.Add(taskID, string(task.TypeNodeReinstall), string(task.StatusInProgress), nodegroupID)
+		status := task.StatusDone
+		if fake.failNodes[nodeID] {
+			status = task.StatusError
+		}
+		time.AfterFunc(20*time.Millisecond, func() {
+			fake.mu.Lock()
+			defer fake.mu.Unlock()
+			fake.inFlight--
+			fakeTasks.SetStatus(taskID, string(status))
+		})
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	return fake
+}
+
+func TestRollingReinstall(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	fake := handleFakeReinstall(t, testEnv)
+
+	state, err := nodegroup.RollingReinstall(context.Background(), newCreateTestClient(testEnv), clusterID, nodegroupID,
+		&nodegroup.RollingReinstallOpts{BatchSize: 4, MaxUnavailable: 2, PollInterval: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(state.Done, rollingReinstallNodes) || len(state.Failed) != 0 {
+		t.Fatalf("expected all nodes to be done, but got %#v", state)
+	}
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	if !reflect.DeepEqual(fake.reinstalled, rollingReinstallNodes) {
+		t.Fatalf("expected %v nodes to be reinstalled, but got %v", rollingReinstallNodes, fake.reinstalled)
+	}
+	if fake.maxInFlight > 2 {
+		t.Fatalf("expected at most 2 nodes to be reinstalled at once, but got %d", fake.maxInFlight)
+	}
+}
+
+func TestRollingReinstallIgnoresEarlierTasks(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	fake := handleFakeReinstall(t, testEnv)
+	fake.autorepair = true
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	state, err := nodegroup.RollingReinstall(ctx, newCreateTestClient(testEnv), clusterID, nodegroupID,
+		&nodegroup.RollingReinstallOpts{BatchSize: 2, MaxUnavailable: 2, PollInterval: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(state.Done, rollingReinstallNodes) {
+		t.Fatalf("expected all nodes to be done, but got %#v", state)
+	}
+}
+
+func TestRollingReinstallResume(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	fake := handleFakeReinstall(t, testEnv)
+	fake.failNodes["node-3"] = true
+
+	ctx := context.Background()
+	opts := &nodegroup.RollingReinstallOpts{BatchSize: 2, PollInterval: time.Millisecond}
+	state, err := nodegroup.RollingReinstall(ctx, newCreateTestClient(testEnv), clusterID, nodegroupID, opts)
+	if !errors.Is(err, task.ErrTaskFailed) {
+		t.Fatalf("expected ErrTaskFailed, but got %v", err)
+	}
+	expected := &nodegroup.RollingReinstallState{
+		Done:   []string{"node-1", "node-2"},
+		Failed: []string{"node-3"},
+	}
+	if !reflect.DeepEqual(expected, state) {
+		t.Fatalf("expected %#v, but got %#v", expected, state)
+	}
+
+	fake.mu.Lock()
+	fake.failNodes = map[string]bool{}
+	fake.reinstalled = nil
+	fake.mu.Unlock()
+
+	opts.State = state
+	state, err = nodegroup.RollingReinstall(ctx, newCreateTestClient(testEnv), clusterID, nodegroupID, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(state.Done, rollingReinstallNodes) || len(state.Failed) != 0 {
+		t.Fatalf("expected all nodes to be done, but got %#v", state)
+	}
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	if expected := []string{"node-3", "node-4", "node-5"}; !reflect.DeepEqual(fake.reinstalled, expected) {
+		t.Fatalf("expected %v nodes to be reinstalled on resume, but got %v", expected, fake.reinstalled)
+	}
+}
+
+func TestRollingReinstallInvalidOpts(t *testing.T) {
+	_, err := nodegroup.RollingReinstall(context.Background(), nil, clusterID, nodegroupID,
+		&nodegroup.RollingReinstallOpts{BatchSize: -1})
+	if !errors.Is(err, nodegroup.ErrInvalidRollingReinstallOpts) {
+		t.Fatalf("expected ErrInvalidRollingReinstallOpts, but got %v", err)
+	}
+}
@@ -0,0 +1,164 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/selectel/mks-go/pkg/testutils"
+	"github.com/selectel/mks-go/pkg/v1/nodegroup"
+)
+
+func noContent(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func TestReplace(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	fake := handleFakeNodegroups(testEnv.Mux, noContent)
+
+	var drained []string
+	actual, err := nodegroup.Replace(context.Background(), newCreateTestClient(testEnv), clusterID, nodegroupID,
+		&nodegroup.ReplaceOpts{
+			Modify: func(opts *nodegroup.CreateOpts) {
+				opts.FlavorID = "new-flavor"
+			},
+			DrainHook: nodegroup.DrainHookFunc(func(ctx context.Context, oldNodegroup, newNodegroup *nodegroup.GetView) error {
+				drained = append(drained, oldNodegroup.ID, newNodegroup.ID)
+
+				return nil
+			}),
+			PollInterval: time.Millisecond,
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual.ID != createdNodegroupID || actual.Status != nodegroup.StatusActive {
+		t.Fatalf("expected active %s nodegroup, but got %#v", createdNodegroupID, actual)
+	}
+
+	expectedOpts := &nodegroup.CreateOpts{
+		FlavorID:         "new-flavor",
+		AvailabilityZone: "ru-3a",
+		Labels:           map[string]string{"app": "web"},
+		Taints:           []nodegroup.Taint{},
+	}
+	if !reflect.DeepEqual(expectedOpts, fake.createOpts) {
+		t.Fatalf("expected %#v create options, but got %#v", expectedOpts, fake.createOpts)
+	}
+	if expected := []string{nodegroupID, createdNodegroupID}; !reflect.DeepEqual(expected, drained) {
+		t.Fatalf("expected drain hook to be called with %v, but got %v", expected, drained)
+	}
+	if expected := []string{nodegroupID}; !reflect.DeepEqual(expected, fake.deleted) {
+		t.Fatalf("expected %v nodegroups to be deleted, but got %v", expected, fake.deleted)
+	}
+}
+
+func TestReplaceRollback(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	fake := handleFakeNodegroups(testEnv.Mux, noContent)
+	fake.failCreated = true
+
+	drainCalled := false
+	_, err := nodegroup.Replace(context.Background(), newCreateTestClient(testEnv), clusterID, nodegroupID,
+		&nodegroup.ReplaceOpts{
+			DrainHook: nodegroup.DrainHookFunc(func(context.Context, *nodegroup.GetView, *nodegroup.GetView) error {
+				drainCalled = true
+
+				return nil
+			}),
+			PollInterval: time.Millisecond,
+		})
+	if !errors.Is(err, nodegroup.ErrReplaceRolledBack) || !errors.Is(err, nodegroup.ErrNodegroupError) {
+		t.Fatalf("expected ErrReplaceRolledBack caused by ErrNodegroupError, but got %v", err)
+	}
+	if drainCalled {
+		t.Fatal("expected drain hook not to be called")
+	}
+	if expected := []string{createdNodegroupID}; !reflect.DeepEqual(expected, fake.deleted) {
+		t.Fatalf("expected %v nodegroups to be deleted, but got %v", expected, fake.deleted)
+	}
+}
+
+func TestReplaceDrainError(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	fake := handleFakeNodegroups(testEnv.Mux, noContent)
+
+	errDrain := errors.New("pods can't be evicted")
+	actual, err := nodegroup.Replace(context.Background(), newCreateTestClient(testEnv), clusterID, nodegroupID,
+		&nodegroup.ReplaceOpts{
+			DrainHook: nodegroup.DrainHookFunc(func(context.Context, *nodegroup.GetView, *nodegroup.GetView) error {
+				return errDrain
+			}),
+			PollInterval: time.Millisecond,
+		})
+	if !errors.Is(err, errDrain) {
+		t.Fatalf("expected drain error, but got %v", err)
+	}
+	if actual == nil || actual.ID != createdNodegroupID {
+		t.Fatalf("expected the new nodegroup to be returned, but got %#v", actual)
+	}
+	if len(fake.deleted) != 0 {
+		t.Fatalf("expected no nodegroups to be deleted, but got %v", fake.deleted)
+	}
+}
+
+func TestReplaceWaitError(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	fake := handleFakeNodegroups(testEnv.Mux, noContent)
+	fake.failGetCreated = true
+
+	actual, err := nodegroup.Replace(context.Background(), newCreateTestClient(testEnv), clusterID, nodegroupID,
+		&nodegroup.ReplaceOpts{PollInterval: time.Millisecond})
+	if err == nil || errors.Is(err, nodegroup.ErrReplaceRolledBack) {
+		t.Fatalf("expected the request error without a rollback, but got %v", err)
+	}
+	if actual == nil || actual.ID != createdNodegroupID {
+		t.Fatalf("expected the new nodegroup to be returned, but got %#v", actual)
+	}
+	if len(fake.deleted) != 0 {
+		t.Fatalf("expected no nodegroups to be deleted, but got %v", fake.deleted)
+	}
+}
+
+func TestReplaceAmbiguousWithoutMarkerLabel(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	fake := handleFakeNodegroups(testEnv.Mux, noContent)
+	fake.concurrentID = "other-nodegroup"
+
+	_, err := nodegroup.Replace(context.Background(), newCreateTestClient(testEnv), clusterID, nodegroupID,
+		&nodegroup.ReplaceOpts{PollInterval: time.Millisecond})
+	if !errors.Is(err, nodegroup.ErrCreatedNodegroupAmbiguous) {
+		t.Fatalf("expected ErrCreatedNodegroupAmbiguous, but got %v", err)
+	}
+	if len(fake.deleted) != 0 {
+		t.Fatalf("expected no nodegroups to be deleted, but got %v", fake.deleted)
+	}
+}
+
+func TestReplaceFindsLabelledNodegroup(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	fake := handleFakeNodegroups(testEnv.Mux, noContent)
+	fake.concurrentID = "other-nodegroup"
+
+	actual, err := nodegroup.Replace(context.Background(), newCreateTestClient(testEnv), clusterID, nodegroupID,
+		&nodegroup.ReplaceOpts{PollInterval: time.Millisecond, MarkerLabel: "example.com/replaces"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual.ID != createdNodegroupID {
+		t.Fatalf("expected %s nodegroup, but got %s", createdNodegroupID, actual.ID)
+	}
+	if expected := []string{nodegroupID}; !reflect.DeepEqual(expected, fake.deleted) {
+		t.Fatalf("expected %v nodegroups to be deleted, but got %v", expected, fake.deleted)
+	}
+}
@@ -750,3 +750,51 @@ func TestUpdateNodegroupTimeoutError(t *testing.T) {
 		t.Fatal("expected error from the Update method")
 	}
 }
+
+func TestListNodegroupsUnknownStatus(t *testing.T) {
+	endpointCalled := false
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	testutils.HandleReqWithoutBody(t, &testutils.HandleReqOpts{
+		Mux:         testEnv.Mux,
+		URL:         fmt.Sprintf("/v1/clusters/%s/nodegroups", clusterID),
+		RawResponse: testListNodegroupsUnknownStatusResponseRaw,
+		Method:      http.MethodGet,
+		Status:      http.StatusOK,
+		CallFlag:    &endpointCalled,
+	})
+
+	var reported []string
+	v1.SetUnknownValueHandler(func(kind v1.UnknownValueKind, value string) {
+		reported = append(reported, string(kind)+"="+value)
+	})
+	defer v1.SetUnknownValueHandler(nil)
+
+	ctx := context.Background()
+	testClient := &v1.ServiceClient{
+		HTTPClient: &http.Client{},
+		TokenID:    testutils.TokenID,
+		Endpoint:   testEnv.Server.URL + "/v1",
+		UserAgent:  testutils.UserAgent,
+	}
+
+	actual, _, err := nodegroup.List(ctx, testClient, clusterID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !endpointCalled {
+		t.Fatal("endpoint wasn't called")
+	}
+	if len(actual) != 1 {
+		t.Fatalf("expected 1 nodegroup, but got %d", len(actual))
+	}
+	if actual[0].Status != nodegroup.StatusUnknown || actual[0].RawStatus != "PENDING_MIGRATE" {
+		t.Fatalf("expected UNKNOWN status with PENDING_MIGRATE raw status, but got %s and %s",
+			actual[0].Status, actual[0].RawStatus)
+	}
+	expectedReported := []string{"nodegroup status=PENDING_MIGRATE"}
+	if !reflect.DeepEqual(expectedReported, reported) {
+		t.Fatalf("expected %v reported values, but got %v", expectedReported, reported)
+	}
+}
@@ -0,0 +1,214 @@
+package testing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/selectel/mks-go/pkg/testutils"
+	"github.com/selectel/mks-go/pkg/v1/node"
+	"github.com/selectel/mks-go/pkg/v1/nodegroup"
+	"github.com/selectel/mks-go/pkg/v1/task"
+)
+
+func TestPlanSpread(t *testing.T) {
+	template := &nodegroup.CreateOpts{
+		FlavorID:         "1015",
+		VolumeGB:         20,
+		VolumeType:       "fast.ru-3a",
+		AvailabilityZone: "ru-3a",
+		Labels:           map[string]string{"app": "web"},
+	}
+
+	plan, err := nodegroup.PlanSpread(7, []string{"ru-3a", "ru-3b", "ru-3c"}, template)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*nodegroup.CreateOpts{
+		{
+			Count: 3, FlavorID: "1015", VolumeGB: 20, VolumeType: "fast.ru-3a", AvailabilityZone: "ru-3a",
+			Labels: map[string]string{"app": "web", nodegroup.SpreadZoneLabel: "ru-3a"},
+		},
+		{
+			Count: 2, FlavorID: "1015", VolumeGB: 20, VolumeType: "fast.ru-3b", AvailabilityZone: "ru-3b",
+			Labels: map[string]string{"app": "web", nodegroup.SpreadZoneLabel: "ru-3b"},
+		},
+		{
+			Count: 2, FlavorID: "1015", VolumeGB: 20, VolumeType: "fast.ru-3c", AvailabilityZone: "ru-3c",
+			Labels: map[string]string{"app": "web", nodegroup.SpreadZoneLabel: "ru-3c"},
+		},
+	}
+	if !reflect.DeepEqual(expected, plan) {
+		t.Fatalf("expected %#v, but got %#v", expected, plan)
+	}
+	if len(template.Labels) != 1 {
+		t.Fatalf("expected the template labels not to be changed, but got %v", template.Labels)
+	}
+}
+
+func TestPlanSpreadInvalid(t *testing.T) {
+	testCases := map[string]struct {
+		total int
+		zones []string
+	}{
+		"no zones":       {total: 3},
+		"too few nodes":  {total: 1, zones: []string{"ru-3a", "ru-3b"}},
+		"duplicate zone": {total: 4, zones: []string{"ru-3a", "ru-3a"}},
+	}
+
+	for name, testCase := range testCases {
+		_, err := nodegroup.PlanSpread(testCase.total, testCase.zones, &nodegroup.CreateOpts{})
+		if !errors.Is(err, nodegroup.ErrInvalidSpread) {
+			t.Fatalf("%s: expected ErrInvalidSpread, but got %v", name, err)
+		}
+	}
+}
+
+func newSpreadNodegroup(id, zone string, count int) *nodegroup.ListView {
+	ng := &nodegroup.ListView{}
+	ng.ID = id
+	ng.AvailabilityZone = zone
+	ng.Labels = map[string]string{nodegroup.SpreadZoneLabel: zone}
+	for i := 0; i < count; i++ {
+		ng.Nodes = append(ng.Nodes, &node.View{ID: fmt.Sprintf("%s-node-%d", id, i)})
+	}
+
+	return ng
+}
+
+func TestPlanRebalance(t *testing.T) {
+	nodegroups := []*nodegroup.ListView{
+		newSpreadNodegroup("nodegroup-a", "ru-3a", 5),
+		newSpreadNodegroup("nodegroup-b", "ru-3b", 1),
+		newSpreadNodegroup("nodegroup-c", "ru-3c", 2),
+	}
+
+	calls, err := nodegroup.PlanRebalance(nodegroups, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []nodegroup.ResizeCall{
+		{NodegroupID: "nodegroup-c", AvailabilityZone: "ru-3c", Current: 2, Opts: &nodegroup.ResizeOpts{Desired: 3}},
+		{NodegroupID: "nodegroup-b", AvailabilityZone: "ru-3b", Current: 1, Opts: &nodegroup.ResizeOpts{Desired: 2}},
+		{NodegroupID: "nodegroup-a", AvailabilityZone: "ru-3a", Current: 5, Opts: &nodegroup.ResizeOpts{Desired: 3}},
+	}
+	if !reflect.DeepEqual(expected, calls) {
+		t.Fatalf("expected %#v, but got %#v", expected, calls)
+	}
+
+	calls, err = nodegroup.PlanRebalance(nodegroups, 9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 3 || calls[0].Opts.Desired != 3 || calls[1].Opts.Desired != 3 || calls[2].Opts.Desired != 3 {
+		t.Fatalf("expected 3 nodes in every nodegroup, but got %#v", calls)
+	}
+
+	balanced := []*nodegroup.ListView{
+		newSpreadNodegroup("nodegroup-a", "ru-3a", 2),
+		newSpreadNodegroup("nodegroup-b", "ru-3b", 3),
+	}
+	calls, err = nodegroup.PlanRebalance(balanced, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 0 {
+		t.Fatalf("expected no resize requests for balanced nodegroups, but got %#v", calls)
+	}
+
+	duplicate := []*nodegroup.ListView{
+		newSpreadNodegroup("nodegroup-a", "ru-3a", 2),
+		newSpreadNodegroup("nodegroup-c", "ru-3a", 1),
+	}
+	if _, err := nodegroup.PlanRebalance(duplicate, 0); !errors.Is(err, nodegroup.ErrInvalidSpread) {
+		t.Fatalf("expected ErrInvalidSpread, but got %v", err)
+	}
+}
+
+func TestRebalance(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	fakeTasks := testutils.HandleFakeTasks(testEnv.Mux, clusterID)
+
+	unlabeled := newSpreadNodegroup("nodegroup-other", "ru-3a", 10)
+	unlabeled.Labels = nil
+	nodegroups := []*nodegroup.ListView{
+		newSpreadNodegroup("nodegroup-a", "ru-3a", 4),
+		newSpreadNodegroup("nodegroup-b", "ru-3b", 2),
+		unlabeled,
+	}
+	nodegroupsURL := fmt.Sprintf("/v1/clusters/%s/nodegroups", clusterID)
+	testEnv.Mux.HandleFunc(nodegroupsURL, func(w http.ResponseWriter, r *http.Request) {
+		writeNodegroupsJSON(w, map[string]interface{}{"nodegroups": nodegroups})
+	})
+
+	// The cluster accepts a single resize at once, the resize is finished after a while.
+	var (
+		mu       sync.Mutex
+		pending  string
+		resized  []string
+		rejected int
+	)
+	testEnv.Mux.HandleFunc(nodegroupsURL+"/", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		path := strings.TrimPrefix(r.URL.Path, nodegroupsURL+"/")
+		if !strings.HasSuffix(path, "/resize") {
+			status := string(nodegroup.StatusActive)
+			if path == pending {
+				status = string(nodegroup.StatusPendingScaleUp)
+			}
+			writeNodegroupsJSON(w, map[string]interface{}{"nodegroup": map[string]string{"id": path, "status": status}})
+
+			return
+		}
+		if pending != "" {
+			rejected++
+			w.WriteHeader(http.StatusConflict)
+
+			return
+		}
+		var body struct {
+			Nodegroup nodegroup.ResizeOpts `json:"nodegroup"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		pending = strings.TrimSuffix(path, "/resize")
+		resized = append(resized, fmt.Sprintf("%s=%d", pending, body.Nodegroup.Desired))
+		taskID := "resize-" + pending
+		fakeTasks.Add(taskID, string(task.TypeNodeGroupResize), string(task.StatusInProgress), pending)
+		time.AfterFunc(20*time.Millisecond, func() {
+			mu.Lock()
+			defer mu.Unlock()
+			pending = ""
+			fakeTasks.SetStatus(taskID, string(task.StatusDone))
+		})
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	calls, err := nodegroup.Rebalance(context.Background(), newCreateTestClient(testEnv), clusterID,
+		&nodegroup.RebalanceOpts{PollInterval: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 2 {
+		t.Fatalf("expected 2 resize requests, but got %#v", calls)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if expected := []string{"nodegroup-b=3", "nodegroup-a=3"}; !reflect.DeepEqual(expected, resized) {
+		t.Fatalf("expected %v resize requests, but got %v", expected, resized)
+	}
+	if rejected != 0 {
+		t.Fatalf("expected no resize requests while another one is pending, but got %d", rejected)
+	}
+}
@@ -0,0 +1,59 @@
+package testing
+
+import (
+	"testing"
+
+	"github.com/selectel/mks-go/pkg/v1/nodegroup"
+)
+
+func TestStatusClassification(t *testing.T) {
+	testCases := []struct {
+		status   nodegroup.Status
+		stable   bool
+		isError  bool
+		pending  bool
+		terminal bool
+	}{
+		{status: nodegroup.StatusActive, stable: true, terminal: true},
+		{status: nodegroup.StatusError, isError: true, terminal: true},
+		{status: nodegroup.StatusPendingScaleUp, pending: true},
+		{status: nodegroup.StatusPendingNodeReinstall, pending: true},
+		{status: nodegroup.StatusUnknown},
+	}
+	for _, tc := range testCases {
+		if tc.status.IsStable() != tc.stable {
+			t.Fatalf("expected IsStable of %s to be %v", tc.status, tc.stable)
+		}
+		if tc.status.IsError() != tc.isError {
+			t.Fatalf("expected IsError of %s to be %v", tc.status, tc.isError)
+		}
+		if tc.status.IsPending() != tc.pending {
+			t.Fatalf("expected IsPending of %s to be %v", tc.status, tc.pending)
+		}
+		if tc.status.IsTerminal() != tc.terminal {
+			t.Fatalf("expected IsTerminal of %s to be %v", tc.status, tc.terminal)
+		}
+	}
+}
+
+func TestStatusCanPerform(t *testing.T) {
+	testCases := []struct {
+		status    nodegroup.Status
+		operation nodegroup.Operation
+		expected  bool
+	}{
+		{status: nodegroup.StatusActive, operation: nodegroup.OperationResize, expected: true},
+		{status: nodegroup.StatusActive, operation: nodegroup.OperationUpdate, expected: true},
+		{status: nodegroup.StatusPendingScaleUp, operation: nodegroup.OperationResize, expected: false},
+		{status: nodegroup.StatusPendingScaleUp, operation: nodegroup.OperationDelete, expected: true},
+		{status: nodegroup.StatusPendingDelete, operation: nodegroup.OperationDelete, expected: false},
+		{status: nodegroup.StatusError, operation: nodegroup.OperationUpdate, expected: false},
+		{status: nodegroup.StatusError, operation: nodegroup.OperationDelete, expected: true},
+		{status: nodegroup.StatusUnknown, operation: nodegroup.OperationResize, expected: true},
+	}
+	for _, tc := range testCases {
+		if actual := tc.status.CanPerform(tc.operation); actual != tc.expected {
+			t.Fatalf("expected CanPerform(%s) of %s to be %v", tc.operation, tc.status, tc.expected)
+		}
+	}
+}
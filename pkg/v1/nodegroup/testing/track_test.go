@@ -0,0 +1,50 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/selectel/mks-go/pkg/testutils"
+	v1 "github.com/selectel/mks-go/pkg/v1"
+	"github.com/selectel/mks-go/pkg/v1/nodegroup"
+	"github.com/selectel/mks-go/pkg/v1/task"
+)
+
+func TestResizeAndTrack(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	fakeTasks := testutils.HandleFakeTasks(testEnv.Mux, clusterID)
+	testEnv.Mux.HandleFunc(fmt.Sprintf("/v1/clusters/%s/nodegroups/%s/resize", clusterID, nodegroupID),
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				t.Fatalf("expected %s method but got %s", http.MethodPost, r.Method)
+			}
+			fakeTasks.Add("resize", string(task.TypeNodeGroupResize), string(task.StatusDone), nodegroupID)
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+	ctx := context.Background()
+	testClient := &v1.ServiceClient{
+		HTTPClient: &http.Client{},
+		TokenID:    testutils.TokenID,
+		Endpoint:   testEnv.Server.URL + "/v1",
+		UserAgent:  testutils.UserAgent,
+	}
+
+	operation, _, err := nodegroup.ResizeAndTrack(ctx, testClient, clusterID, nodegroupID, &nodegroup.ResizeOpts{Desired: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	operation.PollInterval = time.Millisecond
+
+	actual, err := operation.Wait(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual.ID != "resize" || actual.NodeGroupID != nodegroupID {
+		t.Fatalf("expected resize task of the nodegroup, but got %#v", actual)
+	}
+}
@@ -0,0 +1,46 @@
+package nodegroup
+
+import (
+	"context"
+
+	v1 "github.com/selectel/mks-go/pkg/v1"
+	"github.com/selectel/mks-go/pkg/v1/task"
+)
+
+// CreateAndTrack requests a creation of a new nodegroup in the cluster and returns
+// an operation that tracks the spawned cluster resize task.
+func CreateAndTrack(ctx context.Context, client *v1.ServiceClient, clusterID string,
+	opts *CreateOpts,
+) (*task.Operation, *v1.ResponseResult, error) {
+	operation, err := task.NewOperation(ctx, client, clusterID, task.TypeClusterResize, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responseResult, err := Create(ctx, client, clusterID, opts)
+	if err != nil {
+		return nil, responseResult, err
+	}
+	operation.SetResponse(responseResult)
+
+	return operation, responseResult, nil
+}
+
+// ResizeAndTrack requests a resize of a cluster nodegroup by its id and returns
+// an operation that tracks the spawned task.
+func ResizeAndTrack(ctx context.Context, client *v1.ServiceClient, clusterID, nodegroupID string,
+	opts *ResizeOpts,
+) (*task.Operation, *v1.ResponseResult, error) {
+	operation, err := task.NewOperation(ctx, client, clusterID, task.TypeNodeGroupResize, nodegroupID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responseResult, err := Resize(ctx, client, clusterID, nodegroupID, opts)
+	if err != nil {
+		return nil, responseResult, err
+	}
+	operation.SetResponse(responseResult)
+
+	return operation, responseResult, nil
+}
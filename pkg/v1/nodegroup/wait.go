@@ -0,0 +1,42 @@
+package nodegroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	v1 "github.com/selectel/mks-go/pkg/v1"
+	"github.com/selectel/mks-go/pkg/v1/task"
+)
+
+// ErrNodegroupError is returned when a nodegroup has the error status.
+var ErrNodegroupError = errors.New("nodegroup has the error status")
+
+// WaitForActive waits until a cluster nodegroup referenced by its id has the active status and returns it.
+// ErrNodegroupError is returned along with the nodegroup if the nodegroup has the error status.
+// task.DefaultPollInterval is used if the provided interval is not positive.
+func WaitForActive(ctx context.Context, client *v1.ServiceClient, clusterID, nodegroupID string,
+	interval time.Duration,
+) (*GetView, error) {
+	if interval <= 0 {
+		interval = task.DefaultPollInterval
+	}
+
+	for {
+		mksNodegroup, _, err := Get(ctx, client, clusterID, nodegroupID)
+		if err != nil {
+			return nil, err
+		}
+		if mksNodegroup.Status.IsStable() {
+			return mksNodegroup, nil
+		}
+		if mksNodegroup.Status.IsError() {
+			return mksNodegroup, fmt.Errorf("%w: %s", ErrNodegroupError, mksNodegroup.ID)
+		}
+
+		if err := v1.Sleep(ctx, interval); err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,36 @@
+/*
+Package preemptible provides the ability to track interruptions of preemptible nodegroups
+and to recreate disappeared nodes through the MKS V1 API.
+
+Example of running the reconciler with a grace period for the platform
+
+	reconciler := preemptible.New(mksClient, clusterID, &preemptible.Opts{
+	  Interval:    time.Minute,
+	  GracePeriod: 10 * time.Minute,
+	  Handlers: []preemptible.Handler{
+	    preemptible.HandlerFunc(func(ctx context.Context, event preemptible.Event) error {
+	      log.Printf("%s nodegroup=%s node=%s nodes=%d/%d",
+	        event.Type, event.NodegroupID, event.NodeID, event.Current, event.Desired)
+	      return nil
+	    }),
+	  },
+	  ErrorHandler: func(err error) {
+	    log.Println(err)
+	  },
+	})
+	if err := reconciler.Run(ctx); err != nil {
+	  log.Println(err)
+	}
+
+Example of querying interruptions of the last day
+
+	interruptions := reconciler.History().Query(&preemptible.HistoryFilter{
+	  NodegroupID: nodegroupID,
+	  Since:       time.Now().Add(-24 * time.Hour),
+	})
+	for _, interruption := range interruptions {
+	  fmt.Printf("%s interrupted at %s, recovered by %q\n",
+	    interruption.NodeID, interruption.DetectedAt, interruption.RecoveredBy)
+	}
+*/
+package preemptible
@@ -0,0 +1,9 @@
+package preemptible
+
+import "github.com/selectel/mks-go/pkg/v1/internal/polling"
+
+// Handler handles preemptible reconciler events.
+type Handler = polling.Handler[Event]
+
+// HandlerFunc is an adapter to use ordinary functions as handlers.
+type HandlerFunc = polling.HandlerFunc[Event]
@@ -0,0 +1,110 @@
+package preemptible
+
+import (
+	"sync"
+	"time"
+)
+
+// HistoryFilter represents a query of interruptions from the history.
+type HistoryFilter struct {
+	// NodegroupID selects interruptions of the nodegroup. Interruptions of all nodegroups
+	// are selected if it's empty.
+	NodegroupID string
+
+	// Since selects interruptions that have been detected at the time or later.
+	Since time.Time
+
+	// Until selects interruptions that have been detected before the time.
+	Until time.Time
+
+	// Unrecovered selects only interruptions that aren't recovered yet.
+	Unrecovered bool
+}
+
+// matches returns true if the interruption is selected by the filter.
+func (filter *HistoryFilter) matches(interruption *Interruption) bool {
+	switch {
+	case filter.NodegroupID != "" && interruption.NodegroupID != filter.NodegroupID:
+		return false
+	case !filter.Since.IsZero() && interruption.DetectedAt.Before(filter.Since):
+		return false
+	case !filter.Until.IsZero() && !interruption.DetectedAt.Before(filter.Until):
+		return false
+	case filter.Unrecovered && interruption.IsRecovered():
+		return false
+	}
+
+	return true
+}
+
+// History keeps interruptions in the order of their detection.
+// It's safe for concurrent use.
+type History struct {
+	mu            sync.Mutex
+	limit         int
+	interruptions []*Interruption
+}
+
+// NewHistory returns an empty history. The oldest interruptions are dropped when
+// the history contains more than limit interruptions. The history isn't limited
+// if limit isn't positive.
+func NewHistory(limit int) *History {
+	return &History{
+		limit: limit,
+	}
+}
+
+// Query returns copies of interruptions selected by the filter.
+// All interruptions are returned if the filter is nil.
+func (history *History) Query(filter *HistoryFilter) []Interruption {
+	history.mu.Lock()
+	defer history.mu.Unlock()
+
+	if filter == nil {
+		filter = &HistoryFilter{}
+	}
+	var result []Interruption
+	for _, interruption := range history.interruptions {
+		if filter.matches(interruption) {
+			result = append(result, *interruption)
+		}
+	}
+
+	return result
+}
+
+// Len returns the amount of interruptions in the history.
+func (history *History) Len() int {
+	history.mu.Lock()
+	defer history.mu.Unlock()
+
+	return len(history.interruptions)
+}
+
+// add appends the interruption and drops the oldest ones that exceed the limit.
+func (history *History) add(interruption *Interruption) {
+	history.mu.Lock()
+	defer history.mu.Unlock()
+
+	history.interruptions = append(history.interruptions, interruption)
+	if history.limit > 0 && len(history.interruptions) > history.limit {
+		history.interruptions = append([]*Interruption(nil),
+			history.interruptions[len(history.interruptions)-history.limit:]...)
+	}
+}
+
+// recover marks unrecovered interruptions of the nodegroup as recovered.
+func (history *History) recover(clusterID, nodegroupID string, now time.Time, recoveredBy RecoveredBy) {
+	history.mu.Lock()
+	defer history.mu.Unlock()
+
+	for _, interruption := range history.interruptions {
+		if interruption.ClusterID != clusterID || interruption.NodegroupID != nodegroupID ||
+			interruption.IsRecovered() {
+			continue
+		}
+		recoveredAt := now
+		interruption.RecoveredAt = &recoveredAt
+		interruption.RecoveredBy = recoveredBy
+	}
+}
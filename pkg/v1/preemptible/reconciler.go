@@ -0,0 +1,279 @@
+package preemptible
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "github.com/selectel/mks-go/pkg/v1"
+	"github.com/selectel/mks-go/pkg/v1/internal/polling"
+	"github.com/selectel/mks-go/pkg/v1/node"
+	"github.com/selectel/mks-go/pkg/v1/nodegroup"
+)
+
+// DefaultInterval represents the default interval between reconciler polls.
+const DefaultInterval = time.Minute
+
+// Opts represents options of the preemptible reconciler.
+type Opts struct {
+	// Interval represents the interval between polls. DefaultInterval is used if it's not set.
+	Interval time.Duration
+
+	// GracePeriod represents how long the platform is given to recreate disappeared nodes
+	// before the reconciler resizes the nodegroup. It's also the interval between repeated
+	// Resize requests. Nodegroups are resized on the first poll that finds missing nodes
+	// if it's not set.
+	GracePeriod time.Duration
+
+	// DesiredCounts contains desired amounts of nodes by nodegroup identifiers.
+	// The desired amount of other nodegroups is the largest amount of nodes seen by
+	// the reconciler, it's lowered only by scale downs and deletions of the nodegroup.
+	DesiredCounts map[string]int
+
+	// DisableRecovery disables Resize requests, interruptions are recorded only.
+	DisableRecovery bool
+
+	// History receives interruptions. A new unlimited history is used if it's not set.
+	History *History
+
+	// Handlers receive every event in order.
+	Handlers []Handler
+
+	// ErrorHandler receives errors of polls, Resize requests and handlers.
+	// Errors are ignored if it's not set.
+	ErrorHandler func(err error)
+}
+
+// Reconciler polls preemptible nodegroups of a cluster, records nodes that have
+// disappeared and resizes nodegroups back to the desired amount of nodes.
+type Reconciler struct {
+	client    *v1.ServiceClient
+	clusterID string
+	opts      Opts
+	poller    *polling.Poller[Event]
+
+	// nodegroups contains states of preemptible nodegroups found by the previous poll.
+	nodegroups map[string]*nodegroupState
+}
+
+// nodegroupState represents the tracked state of a preemptible nodegroup.
+type nodegroupState struct {
+	desired int
+	nodes   map[string]*node.View
+
+	// disruptedSince is the time of the first poll that has found an interruption
+	// or a lack of nodes. It's zero if the nodegroup isn't disrupted.
+	disruptedSince time.Time
+
+	// resizedAt is the time of the last Resize request of the current disruption.
+	resizedAt time.Time
+}
+
+// New returns a reconciler of preemptible nodegroups of the cluster.
+// Nodes that exist at the first poll are remembered without events.
+func New(client *v1.ServiceClient, clusterID string, opts *Opts) *Reconciler {
+	r := &Reconciler{
+		client:     client,
+		clusterID:  clusterID,
+		nodegroups: map[string]*nodegroupState{},
+	}
+	if opts != nil {
+		r.opts = *opts
+	}
+	if r.opts.Interval <= 0 {
+		r.opts.Interval = DefaultInterval
+	}
+	if r.opts.History == nil {
+		r.opts.History = NewHistory(0)
+	}
+	r.poller = &polling.Poller[Event]{
+		Interval:     r.opts.Interval,
+		Handlers:     r.opts.Handlers,
+		ErrorHandler: r.opts.ErrorHandler,
+	}
+
+	return r
+}
+
+// History returns the interruption history of the reconciler.
+func (r *Reconciler) History() *History {
+	return r.opts.History
+}
+
+// Run polls the cluster until the context is done and returns the context error.
+func (r *Reconciler) Run(ctx context.Context) error {
+	return r.poller.Run(ctx, func(ctx context.Context) error {
+		_, err := r.Poll(ctx)
+
+		return err
+	})
+}
+
+// Poll reconciles preemptible nodegroups of the cluster once, passes events
+// to the handlers and returns them.
+func (r *Reconciler) Poll(ctx context.Context) ([]Event, error) {
+	nodegroups, _, err := nodegroup.List(ctx, r.client, r.clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	states := make(map[string]*nodegroupState, len(nodegroups))
+	var events []Event
+	for _, ng := range nodegroups {
+		if !ng.Preemptible {
+			continue
+		}
+		state, ok := r.nodegroups[ng.ID]
+		if !ok {
+			state = r.newState(ng)
+		}
+		events = append(events, r.reconcile(ctx, ng, state, now)...)
+		states[ng.ID] = state
+	}
+	r.nodegroups = states
+	r.poller.Dispatch(ctx, events)
+
+	return events, nil
+}
+
+// newState returns the state of a nodegroup that is found for the first time.
+func (r *Reconciler) newState(ng *nodegroup.ListView) *nodegroupState {
+	desired, ok := r.opts.DesiredCounts[ng.ID]
+	if !ok {
+		desired = len(ng.Nodes)
+	}
+
+	return &nodegroupState{
+		desired: desired,
+		nodes:   map[string]*node.View{},
+	}
+}
+
+// reconcile updates the nodegroup state and returns events about its changes.
+func (r *Reconciler) reconcile(ctx context.Context, ng *nodegroup.ListView, state *nodegroupState,
+	now time.Time,
+) []Event {
+	scaledDown := r.updateDesired(ng, state)
+	base := Event{Time: now, ClusterID: r.clusterID, NodegroupID: ng.ID, Desired: state.desired, Current: len(ng.Nodes)}
+
+	var events []Event
+	if !scaledDown {
+		events = r.detectInterruptions(ng, state, base)
+	}
+	state.nodes = make(map[string]*node.View, len(ng.Nodes))
+	for _, nodeView := range ng.Nodes {
+		state.nodes[nodeView.ID] = nodeView
+	}
+
+	if base.Current >= state.desired {
+		if !state.disruptedSince.IsZero() {
+			events = append(events, r.markRecovered(state, base))
+		}
+
+		return events
+	}
+
+	if state.disruptedSince.IsZero() {
+		state.disruptedSince = now
+	}
+	if event, ok := r.recoverNodegroup(ctx, ng, state, base); ok {
+		events = append(events, event)
+	}
+
+	return events
+}
+
+// updateDesired raises the desired amount of nodes to the current one if it isn't pinned
+// by DesiredCounts. The desired amount is lowered by scale downs and deletions as well,
+// it returns true for such nodegroups since their nodes are removed on purpose.
+func (r *Reconciler) updateDesired(ng *nodegroup.ListView, state *nodegroupState) bool {
+	count := len(ng.Nodes)
+	scaledDown := ng.Status == nodegroup.StatusPendingScaleDown || ng.Status == nodegroup.StatusPendingDelete
+	if _, pinned := r.opts.DesiredCounts[ng.ID]; !pinned && (count > state.desired || scaledDown) {
+		state.desired = count
+	}
+
+	return scaledDown
+}
+
+// markRecovered finishes the nodegroup disruption and its interruptions.
+func (r *Reconciler) markRecovered(state *nodegroupState, base Event) Event {
+	recoveredBy := RecoveredByPlatform
+	if !state.resizedAt.IsZero() {
+		recoveredBy = RecoveredByReconciler
+	}
+	r.opts.History.recover(r.clusterID, base.NodegroupID, base.Time, recoveredBy)
+	state.disruptedSince, state.resizedAt = time.Time{}, time.Time{}
+	base.Type = EventNodegroupRecovered
+
+	return base
+}
+
+// detectInterruptions records nodes of the previous poll that are absent in the nodegroup.
+func (r *Reconciler) detectInterruptions(ng *nodegroup.ListView, state *nodegroupState, base Event) []Event {
+	current := make(map[string]struct{}, len(ng.Nodes))
+	for _, nodeView := range ng.Nodes {
+		current[nodeView.ID] = struct{}{}
+	}
+
+	var events []Event
+	for _, nodeID := range polling.SortedKeys(state.nodes) {
+		if _, ok := current[nodeID]; ok {
+			continue
+		}
+		nodeView := state.nodes[nodeID]
+		r.opts.History.add(&Interruption{
+			ClusterID:     r.clusterID,
+			NodegroupID:   ng.ID,
+			NodeID:        nodeID,
+			Hostname:      nodeView.Hostname,
+			OSServerID:    nodeView.OSServerID,
+			NodeCreatedAt: nodeView.CreatedAt,
+			DetectedAt:    base.Time,
+		})
+		event := base
+		event.Type = EventNodeInterrupted
+		event.NodeID = nodeID
+		events = append(events, event)
+	}
+	if len(events) > 0 && state.disruptedSince.IsZero() {
+		state.disruptedSince = base.Time
+	}
+
+	return events
+}
+
+// recoverNodegroup resizes the nodegroup to the desired amount of nodes if the platform
+// hasn't recreated nodes during the grace period. Autoscaled nodegroups and nodegroups
+// that are being changed are left to the platform.
+func (r *Reconciler) recoverNodegroup(ctx context.Context, ng *nodegroup.ListView, state *nodegroupState,
+	base Event,
+) (Event, bool) {
+	if r.opts.DisableRecovery || ng.EnableAutoscale || ng.Status.IsPending() {
+		return Event{}, false
+	}
+	waitingSince := state.disruptedSince
+	if !state.resizedAt.IsZero() {
+		waitingSince = state.resizedAt
+	}
+	if base.Time.Sub(waitingSince) < r.opts.GracePeriod {
+		return Event{}, false
+	}
+
+	opts := &nodegroup.ResizeOpts{
+		Desired: state.desired,
+	}
+	if _, err := nodegroup.Resize(ctx, r.client, r.clusterID, ng.ID, opts); err != nil {
+		err = fmt.Errorf("resize nodegroup %s: %w", ng.ID, err)
+		r.poller.ReportError(err)
+		base.Type = EventRecoveryFailed
+		base.Error = err.Error()
+
+		return base, true
+	}
+	state.resizedAt = base.Time
+	base.Type = EventRecoveryRequested
+
+	return base, true
+}
@@ -0,0 +1,95 @@
+package preemptible
+
+import "time"
+
+// EventType represents custom type for preemptible reconciler events.
+type EventType string
+
+const (
+	// EventNodeInterrupted is emitted for every node that has disappeared from a preemptible nodegroup.
+	EventNodeInterrupted EventType = "NODE_INTERRUPTED"
+
+	// EventRecoveryRequested is emitted when the reconciler resizes a nodegroup to recreate nodes.
+	EventRecoveryRequested EventType = "RECOVERY_REQUESTED"
+
+	// EventRecoveryFailed is emitted when the reconciler can't resize a nodegroup.
+	EventRecoveryFailed EventType = "RECOVERY_FAILED"
+
+	// EventNodegroupRecovered is emitted when a nodegroup has the desired amount of nodes again.
+	EventNodegroupRecovered EventType = "NODEGROUP_RECOVERED"
+)
+
+// RecoveredBy represents custom type for the source of an interruption recovery.
+type RecoveredBy string
+
+const (
+	// RecoveredByPlatform means that nodes have been recreated by the platform itself.
+	RecoveredByPlatform RecoveredBy = "PLATFORM"
+
+	// RecoveredByReconciler means that nodes have been recreated after a Resize request of the reconciler.
+	RecoveredByReconciler RecoveredBy = "RECONCILER"
+)
+
+// Event represents a change of a preemptible nodegroup found by the reconciler.
+type Event struct {
+	// Type represents the type of the event.
+	Type EventType `json:"type"`
+
+	// Time represents the time of the poll that has found the change.
+	Time time.Time `json:"time"`
+
+	// ClusterID contains the cluster identifier.
+	ClusterID string `json:"cluster_id"`
+
+	// NodegroupID contains the nodegroup identifier.
+	NodegroupID string `json:"nodegroup_id"`
+
+	// NodeID contains the node identifier. It's set for EventNodeInterrupted events.
+	NodeID string `json:"node_id,omitempty"`
+
+	// Desired represents the desired amount of nodes in the nodegroup.
+	Desired int `json:"desired"`
+
+	// Current represents the current amount of nodes in the nodegroup.
+	Current int `json:"current"`
+
+	// Error contains the error message of EventRecoveryFailed events.
+	Error string `json:"error,omitempty"`
+}
+
+// Interruption represents a node that has disappeared from a preemptible nodegroup.
+type Interruption struct {
+	// ClusterID contains the cluster identifier.
+	ClusterID string `json:"cluster_id"`
+
+	// NodegroupID contains the nodegroup identifier.
+	NodegroupID string `json:"nodegroup_id"`
+
+	// NodeID contains the identifier of the disappeared node.
+	NodeID string `json:"node_id"`
+
+	// Hostname represents the hostname of the disappeared node.
+	Hostname string `json:"hostname"`
+
+	// OSServerID contains OpenStack server identifier of the disappeared node.
+	OSServerID string `json:"os_server_id"`
+
+	// NodeCreatedAt is the timestamp of when the disappeared node has been created.
+	NodeCreatedAt *time.Time `json:"node_created_at"`
+
+	// DetectedAt is the time of the poll that hasn't found the node.
+	DetectedAt time.Time `json:"detected_at"`
+
+	// RecoveredAt is the time of the poll that has found the desired amount of nodes
+	// in the nodegroup again. It's nil while the interruption isn't recovered.
+	RecoveredAt *time.Time `json:"recovered_at"`
+
+	// RecoveredBy represents the source of the recovery. It's empty while the interruption
+	// isn't recovered.
+	RecoveredBy RecoveredBy `json:"recovered_by,omitempty"`
+}
+
+// IsRecovered returns true if the nodegroup has got the desired amount of nodes after the interruption.
+func (interruption *Interruption) IsRecovered() bool {
+	return interruption.RecoveredAt != nil
+}
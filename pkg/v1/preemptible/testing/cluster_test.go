@@ -0,0 +1,149 @@
+package testing
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// testClusterID represents the cluster of the fake nodegroups.
+const testClusterID = "cluster-1"
+
+// fakeNodegroup represents a nodegroup of the fake cluster.
+type fakeNodegroup struct {
+	ID              string
+	Status          string
+	Preemptible     bool
+	EnableAutoscale bool
+	Nodes           []string
+}
+
+// fakeCluster emulates nodegroup list and resize endpoints of the MKS API.
+type fakeCluster struct {
+	mu         sync.Mutex
+	nodegroups []*fakeNodegroup
+
+	// resizes contains desired counts of Resize requests by nodegroup identifiers.
+	resizes map[string][]int
+
+	// failResize makes Resize requests fail.
+	failResize bool
+
+	// nodeSeq is used to name nodes that are created by Resize requests.
+	nodeSeq int
+}
+
+// update changes the nodegroups under the lock.
+func (cluster *fakeCluster) update(f func(nodegroups []*fakeNodegroup)) {
+	cluster.mu.Lock()
+	defer cluster.mu.Unlock()
+
+	f(cluster.nodegroups)
+}
+
+// resizeCalls returns desired counts of Resize requests of the nodegroup.
+func (cluster *fakeCluster) resizeCalls(nodegroupID string) []int {
+	cluster.mu.Lock()
+	defer cluster.mu.Unlock()
+
+	return append([]int(nil), cluster.resizes[nodegroupID]...)
+}
+
+func (cluster *fakeCluster) register(mux *http.ServeMux) {
+	prefix := "/v1/clusters/" + testClusterID + "/nodegroups"
+	mux.HandleFunc(prefix, func(w http.ResponseWriter, r *http.Request) {
+		cluster.mu.Lock()
+		defer cluster.mu.Unlock()
+
+		nodegroups := make([]map[string]interface{}, 0, len(cluster.nodegroups))
+		for _, ng := range cluster.nodegroups {
+			nodes := make([]map[string]interface{}, 0, len(ng.Nodes))
+			for _, nodeID := range ng.Nodes {
+				nodes = append(nodes, map[string]interface{}{
+					"id":           nodeID,
+					"hostname":     "host-" + nodeID,
+					"os_server_id": "server-" + nodeID,
+					"nodegroup_id": ng.ID,
+				})
+			}
+			nodegroups = append(nodegroups, map[string]interface{}{
+				"id":               ng.ID,
+				"cluster_id":       testClusterID,
+				"status":           ng.Status,
+				"preemptible":      ng.Preemptible,
+				"enable_autoscale": ng.EnableAutoscale,
+				"nodes":            nodes,
+			})
+		}
+		writeJSON(w, map[string]interface{}{"nodegroups": nodegroups})
+	})
+	mux.HandleFunc(prefix+"/", func(w http.ResponseWriter, r *http.Request) {
+		cluster.mu.Lock()
+		defer cluster.mu.Unlock()
+
+		nodegroupID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, prefix+"/"), "/resize")
+		if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/resize") {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+		if cluster.failResize {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+		var body struct {
+			Nodegroup struct {
+				Desired int `json:"desired"`
+			} `json:"nodegroup"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+		if cluster.resizes == nil {
+			cluster.resizes = map[string][]int{}
+		}
+		cluster.resizes[nodegroupID] = append(cluster.resizes[nodegroupID], body.Nodegroup.Desired)
+		for _, ng := range cluster.nodegroups {
+			if ng.ID == nodegroupID {
+				ng.Status = "PENDING_SCALE_UP"
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// finishResize creates nodes of the nodegroup up to the count and makes it active.
+func (cluster *fakeCluster) finishResize(nodegroupID string, count int) {
+	cluster.update(func(nodegroups []*fakeNodegroup) {
+		for _, ng := range nodegroups {
+			if ng.ID != nodegroupID {
+				continue
+			}
+			for len(ng.Nodes) < count {
+				cluster.nodeSeq++
+				ng.Nodes = append(ng.Nodes, fmt.Sprintf("new-node-%d", cluster.nodeSeq))
+			}
+			ng.Status = "ACTIVE"
+		}
+	})
+}
+
+func writeJSON(w http.ResponseWriter, body interface{}) {
+	w.Header().Add("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// newTestCluster returns the initial state of the fake cluster.
+func newTestCluster() *fakeCluster {
+	return &fakeCluster{
+		nodegroups: []*fakeNodegroup{
+			{ID: "nodegroup-spot", Status: "ACTIVE", Preemptible: true, Nodes: []string{"node-1", "node-2", "node-3"}},
+			{ID: "nodegroup-regular", Status: "ACTIVE", Nodes: []string{"node-4"}},
+		},
+	}
+}
@@ -0,0 +1,304 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/selectel/mks-go/pkg/testutils"
+	v1 "github.com/selectel/mks-go/pkg/v1"
+	"github.com/selectel/mks-go/pkg/v1/preemptible"
+)
+
+func newTestClient(testEnv *testutils.TestEnv) *v1.ServiceClient {
+	return &v1.ServiceClient{
+		HTTPClient: &http.Client{},
+		TokenID:    testutils.TokenID,
+		Endpoint:   testEnv.Server.URL + "/v1",
+		UserAgent:  testutils.UserAgent,
+	}
+}
+
+func eventTypes(events []preemptible.Event) []string {
+	types := make([]string, 0, len(events))
+	for _, event := range events {
+		description := string(event.Type) + " " + event.NodegroupID
+		if event.NodeID != "" {
+			description += "/" + event.NodeID
+		}
+		types = append(types, description)
+	}
+
+	return types
+}
+
+func pollEvents(t *testing.T, reconciler *preemptible.Reconciler, expected ...string) {
+	t.Helper()
+
+	events, err := reconciler.Poll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual := eventTypes(events); fmt.Sprint(expected) != fmt.Sprint(actual) {
+		t.Fatalf("expected events %v, but got %v", expected, actual)
+	}
+}
+
+func removeNodes(cluster *fakeCluster, nodegroupID string, count int) {
+	cluster.update(func(nodegroups []*fakeNodegroup) {
+		for _, ng := range nodegroups {
+			if ng.ID == nodegroupID {
+				ng.Nodes = ng.Nodes[count:]
+			}
+		}
+	})
+}
+
+func TestPollRecoversByResize(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	cluster := newTestCluster()
+	cluster.register(testEnv.Mux)
+
+	var handled []preemptible.Event
+	reconciler := preemptible.New(newTestClient(testEnv), testClusterID, &preemptible.Opts{
+		Handlers: []preemptible.Handler{
+			preemptible.HandlerFunc(func(_ context.Context, event preemptible.Event) error {
+				handled = append(handled, event)
+
+				return nil
+			}),
+		},
+	})
+
+	pollEvents(t, reconciler)
+
+	removeNodes(cluster, "nodegroup-spot", 1)
+	removeNodes(cluster, "nodegroup-regular", 1)
+	pollEvents(t, reconciler,
+		"NODE_INTERRUPTED nodegroup-spot/node-1",
+		"RECOVERY_REQUESTED nodegroup-spot",
+	)
+	if calls := cluster.resizeCalls("nodegroup-spot"); !reflect.DeepEqual(calls, []int{3}) {
+		t.Fatalf("expected a resize to 3 nodes, but got %v", calls)
+	}
+	if len(cluster.resizeCalls("nodegroup-regular")) != 0 {
+		t.Fatal("expected the regular nodegroup not to be resized")
+	}
+	if len(handled) != 2 || handled[1].Desired != 3 || handled[1].Current != 2 {
+		t.Fatalf("expected handled recovery of 2/3 nodes, but got %+v", handled)
+	}
+
+	interruptions := reconciler.History().Query(nil)
+	if len(interruptions) != 1 {
+		t.Fatalf("expected 1 interruption, but got %d", len(interruptions))
+	}
+	interruption := interruptions[0]
+	if interruption.NodeID != "node-1" || interruption.Hostname != "host-node-1" ||
+		interruption.OSServerID != "server-node-1" || interruption.IsRecovered() {
+		t.Fatalf("unexpected interruption %+v", interruption)
+	}
+
+	// The nodegroup is being resized, so the request isn't repeated.
+	pollEvents(t, reconciler)
+	if calls := cluster.resizeCalls("nodegroup-spot"); len(calls) != 1 {
+		t.Fatalf("expected a single resize, but got %v", calls)
+	}
+
+	cluster.finishResize("nodegroup-spot", 3)
+	pollEvents(t, reconciler, "NODEGROUP_RECOVERED nodegroup-spot")
+
+	interruptions = reconciler.History().Query(nil)
+	if !interruptions[0].IsRecovered() || interruptions[0].RecoveredBy != preemptible.RecoveredByReconciler {
+		t.Fatalf("expected the interruption to be recovered by the reconciler, but got %+v", interruptions[0])
+	}
+}
+
+func TestPollGracePeriod(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	cluster := newTestCluster()
+	cluster.register(testEnv.Mux)
+
+	reconciler := preemptible.New(newTestClient(testEnv), testClusterID, &preemptible.Opts{
+		GracePeriod: time.Hour,
+	})
+	pollEvents(t, reconciler)
+
+	removeNodes(cluster, "nodegroup-spot", 2)
+	pollEvents(t, reconciler,
+		"NODE_INTERRUPTED nodegroup-spot/node-1",
+		"NODE_INTERRUPTED nodegroup-spot/node-2",
+	)
+	pollEvents(t, reconciler)
+	if calls := cluster.resizeCalls("nodegroup-spot"); len(calls) != 0 {
+		t.Fatalf("expected no resizes during the grace period, but got %v", calls)
+	}
+
+	cluster.finishResize("nodegroup-spot", 3)
+	pollEvents(t, reconciler, "NODEGROUP_RECOVERED nodegroup-spot")
+
+	for _, interruption := range reconciler.History().Query(nil) {
+		if interruption.RecoveredBy != preemptible.RecoveredByPlatform {
+			t.Fatalf("expected the interruption to be recovered by the platform, but got %+v", interruption)
+		}
+	}
+}
+
+func TestPollScaleDown(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	cluster := newTestCluster()
+	cluster.register(testEnv.Mux)
+
+	reconciler := preemptible.New(newTestClient(testEnv), testClusterID, nil)
+	pollEvents(t, reconciler)
+
+	cluster.update(func(nodegroups []*fakeNodegroup) {
+		nodegroups[0].Status = "PENDING_SCALE_DOWN"
+		nodegroups[0].Nodes = nodegroups[0].Nodes[1:]
+	})
+	pollEvents(t, reconciler)
+
+	cluster.update(func(nodegroups []*fakeNodegroup) {
+		nodegroups[0].Status = "ACTIVE"
+	})
+	pollEvents(t, reconciler)
+
+	if calls := cluster.resizeCalls("nodegroup-spot"); len(calls) != 0 {
+		t.Fatalf("expected no resizes after a scale down, but got %v", calls)
+	}
+	if reconciler.History().Len() != 0 {
+		t.Fatalf("expected no interruptions after a scale down, but got %+v", reconciler.History().Query(nil))
+	}
+}
+
+func TestPollDesiredCounts(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	cluster := newTestCluster()
+	cluster.register(testEnv.Mux)
+
+	reconciler := preemptible.New(newTestClient(testEnv), testClusterID, &preemptible.Opts{
+		DesiredCounts: map[string]int{"nodegroup-spot": 5},
+	})
+	pollEvents(t, reconciler, "RECOVERY_REQUESTED nodegroup-spot")
+
+	if calls := cluster.resizeCalls("nodegroup-spot"); !reflect.DeepEqual(calls, []int{5}) {
+		t.Fatalf("expected a resize to 5 nodes, but got %v", calls)
+	}
+}
+
+func TestPollDisableRecovery(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	cluster := newTestCluster()
+	cluster.register(testEnv.Mux)
+	cluster.update(func(nodegroups []*fakeNodegroup) {
+		nodegroups[1].Preemptible = true
+		nodegroups[1].EnableAutoscale = true
+	})
+
+	reconciler := preemptible.New(newTestClient(testEnv), testClusterID, &preemptible.Opts{
+		DisableRecovery: true,
+	})
+	pollEvents(t, reconciler)
+
+	removeNodes(cluster, "nodegroup-spot", 1)
+	removeNodes(cluster, "nodegroup-regular", 1)
+	pollEvents(t, reconciler,
+		"NODE_INTERRUPTED nodegroup-spot/node-1",
+		"NODE_INTERRUPTED nodegroup-regular/node-4",
+	)
+	if len(cluster.resizeCalls("nodegroup-spot")) != 0 || len(cluster.resizeCalls("nodegroup-regular")) != 0 {
+		t.Fatal("expected no resizes")
+	}
+}
+
+func TestPollRecoveryFailed(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	cluster := newTestCluster()
+	cluster.failResize = true
+	cluster.register(testEnv.Mux)
+
+	var errs []error
+	reconciler := preemptible.New(newTestClient(testEnv), testClusterID, &preemptible.Opts{
+		ErrorHandler: func(err error) {
+			errs = append(errs, err)
+		},
+	})
+	pollEvents(t, reconciler)
+
+	removeNodes(cluster, "nodegroup-spot", 1)
+	events, err := reconciler.Poll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || events[1].Type != preemptible.EventRecoveryFailed || events[1].Error == "" {
+		t.Fatalf("expected a failed recovery, but got %+v", events)
+	}
+	if len(errs) != 1 {
+		t.Fatalf("expected a reported error, but got %v", errs)
+	}
+}
+
+func TestHistoryQuery(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	cluster := newTestCluster()
+	cluster.register(testEnv.Mux)
+	cluster.update(func(nodegroups []*fakeNodegroup) {
+		nodegroups[1].Preemptible = true
+	})
+
+	history := preemptible.NewHistory(2)
+	reconciler := preemptible.New(newTestClient(testEnv), testClusterID, &preemptible.Opts{
+		GracePeriod: time.Hour,
+		History:     history,
+	})
+	if reconciler.History() != history {
+		t.Fatal("expected the provided history to be used")
+	}
+	pollEvents(t, reconciler)
+
+	removeNodes(cluster, "nodegroup-spot", 1)
+	pollEvents(t, reconciler, "NODE_INTERRUPTED nodegroup-spot/node-1")
+	between := time.Now().UTC()
+
+	removeNodes(cluster, "nodegroup-spot", 1)
+	removeNodes(cluster, "nodegroup-regular", 1)
+	pollEvents(t, reconciler,
+		"NODE_INTERRUPTED nodegroup-spot/node-2",
+		"NODE_INTERRUPTED nodegroup-regular/node-4",
+	)
+	cluster.finishResize("nodegroup-regular", 1)
+	pollEvents(t, reconciler, "NODEGROUP_RECOVERED nodegroup-regular")
+
+	nodeIDs := func(interruptions []preemptible.Interruption) []string {
+		ids := make([]string, 0, len(interruptions))
+		for _, interruption := range interruptions {
+			ids = append(ids, interruption.NodeID)
+		}
+
+		return ids
+	}
+	testCases := []struct {
+		filter   *preemptible.HistoryFilter
+		expected []string
+	}{
+		{filter: nil, expected: []string{"node-2", "node-4"}},
+		{filter: &preemptible.HistoryFilter{NodegroupID: "nodegroup-spot"}, expected: []string{"node-2"}},
+		{filter: &preemptible.HistoryFilter{Unrecovered: true}, expected: []string{"node-2"}},
+		{filter: &preemptible.HistoryFilter{Since: between}, expected: []string{"node-2", "node-4"}},
+		{filter: &preemptible.HistoryFilter{Until: between}, expected: []string{}},
+	}
+	for _, testCase := range testCases {
+		if actual := nodeIDs(history.Query(testCase.filter)); !reflect.DeepEqual(testCase.expected, actual) {
+			t.Fatalf("expected %v for %+v, but got %v", testCase.expected, testCase.filter, actual)
+		}
+	}
+}
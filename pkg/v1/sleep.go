@@ -0,0 +1,20 @@
+package v1
+
+import (
+	"context"
+	"time"
+)
+
+// Sleep pauses the current goroutine for the provided duration or until the context is done.
+// Context error is returned if the context is done before the duration has passed.
+func Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -18,5 +18,55 @@ Example of getting all cluster tasks
 	for _, clusterTask := range clusterTasks {
 	  fmt.Printf("%+v\n", clusterTask)
 	}
+
+Example of waiting until a cluster task is finished
+
+	clusterTask, err := task.Wait(ctx, mksClient, clusterID, taskID, 10*time.Second)
+	if err != nil {
+	  log.Fatal(err)
+	}
+	fmt.Printf("%+v\n", clusterTask)
+
+Example of iterating over cluster tasks page by page
+
+	pager := task.NewPager(mksClient, clusterID, 100)
+	defer pager.Close()
+	for pager.Next(ctx) {
+	  fmt.Printf("%+v\n", pager.Current())
+	}
+	if err := pager.Err(); err != nil {
+	  log.Fatal(err)
+	}
+
+Example of getting tasks that are in progress and the latest resize of a cluster
+
+	runningTasks, _, err := task.InProgress(ctx, mksClient, clusterID)
+	if err != nil {
+	  log.Fatal(err)
+	}
+	for _, runningTask := range runningTasks {
+	  fmt.Printf("%s started at %s\n", runningTask.Type, runningTask.StartedAt)
+	}
+	latestResize, _, err := task.Latest(ctx, mksClient, clusterID, task.TypeNodeGroupResize)
+	if err != nil {
+	  log.Fatal(err)
+	}
+	if latestResize != nil {
+	  fmt.Println(task.Durations([]*task.View{latestResize})[latestResize.ID])
+	}
+
+Example of watching cluster tasks changes
+
+	events := task.Watch(ctx, mksClient, clusterID, &task.WatchOpts{
+	  MinInterval: 5 * time.Second,
+	  MaxInterval: time.Minute,
+	})
+	for event := range events {
+	  if event.Type == task.EventError {
+	    log.Println(event.Err)
+	    continue
+	  }
+	  fmt.Println(event.Type, event.Task.Type, event.Task.Status)
+	}
 */
 package task
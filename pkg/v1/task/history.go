@@ -0,0 +1,124 @@
+package task
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	v1 "github.com/selectel/mks-go/pkg/v1"
+)
+
+// Match returns true if the task matches all filters of the options.
+func (opts *ListOpts) Match(clusterTask *View) bool {
+	return opts.matchType(clusterTask.Type) &&
+		opts.matchStatus(clusterTask.Status) &&
+		(opts.NodeGroupID == "" || clusterTask.NodeGroupID == opts.NodeGroupID) &&
+		inTimeRange(clusterTask.StartedAt, opts.StartedAfter, opts.StartedBefore) &&
+		inTimeRange(clusterTask.UpdatedAt, opts.UpdatedAfter, opts.UpdatedBefore)
+}
+
+func (opts *ListOpts) matchType(taskType Type) bool {
+	if len(opts.Types) == 0 {
+		return true
+	}
+	for _, t := range opts.Types {
+		if t == taskType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (opts *ListOpts) matchStatus(status Status) bool {
+	if len(opts.Statuses) == 0 {
+		return true
+	}
+	for _, s := range opts.Statuses {
+		if s == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// inTimeRange checks that the time is in the [after, before) range.
+// Zero bounds are ignored, nil time doesn't match a non-zero bound.
+func inTimeRange(t *time.Time, after, before time.Time) bool {
+	if after.IsZero() && before.IsZero() {
+		return true
+	}
+	if t == nil {
+		return false
+	}
+
+	return (after.IsZero() || !t.Before(after)) && (before.IsZero() || t.Before(before))
+}
+
+// Filter returns tasks that match the options sorted by the start time.
+// Tasks without the start time are placed last. The provided slice isn't modified.
+// Nil options match all tasks.
+func Filter(tasks []*View, opts *ListOpts) []*View {
+	if opts == nil {
+		opts = &ListOpts{}
+	}
+	filtered := make([]*View, 0, len(tasks))
+	for _, clusterTask := range tasks {
+		if opts.Match(clusterTask) {
+			filtered = append(filtered, clusterTask)
+		}
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		a, b := filtered[i].StartedAt, filtered[j].StartedAt
+		if a == nil || b == nil {
+			return a != nil
+		}
+		if opts.SortDescending {
+			return a.After(*b)
+		}
+
+		return a.Before(*b)
+	})
+
+	return filtered
+}
+
+// Latest returns the most recently started cluster task of the provided type.
+// Nil task is returned if the cluster doesn't have tasks of the type.
+func Latest(ctx context.Context, client *v1.ServiceClient, clusterID string,
+	taskType Type,
+) (*View, *v1.ResponseResult, error) {
+	tasks, responseResult, err := ListWithOpts(ctx, client, clusterID, &ListOpts{
+		Types:          []Type{taskType},
+		SortDescending: true,
+	})
+	if err != nil || len(tasks) == 0 {
+		return nil, responseResult, err
+	}
+
+	return tasks[0], responseResult, nil
+}
+
+// InProgress returns cluster tasks that are in progress sorted by the start time.
+func InProgress(ctx context.Context, client *v1.ServiceClient, clusterID string) ([]*View, *v1.ResponseResult, error) {
+	return ListWithOpts(ctx, client, clusterID, &ListOpts{
+		Statuses: []Status{StatusInProgress},
+	})
+}
+
+// Durations returns durations of finished tasks referenced by their ids.
+// The duration is computed as the time between the start and the last update of a task.
+// Tasks that are in progress or don't have timestamps are skipped.
+func Durations(tasks []*View) map[string]time.Duration {
+	durations := make(map[string]time.Duration, len(tasks))
+	for _, clusterTask := range tasks {
+		if !clusterTask.Status.IsTerminal() || clusterTask.StartedAt == nil || clusterTask.UpdatedAt == nil {
+			continue
+		}
+		durations[clusterTask.ID] = clusterTask.UpdatedAt.Sub(*clusterTask.StartedAt)
+	}
+
+	return durations
+}
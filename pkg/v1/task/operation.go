@@ -0,0 +1,291 @@
+package task
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	v1 "github.com/selectel/mks-go/pkg/v1"
+)
+
+// TaskIDHeader represents the response header that can contain the id of the task
+// spawned by a mutating request. The task is found in the tasks list if the header is absent.
+const TaskIDHeader = "X-Task-Id"
+
+// SnapshotPrecision represents the precision of SnapshotAt. The Date header has one
+// second precision, so StartedAfter is set one second before SnapshotAt by default.
+const SnapshotPrecision = time.Second
+
+// Operation represents a mutating request that spawns a cluster task.
+// It's created before the request to snapshot the tasks list, so the spawned task
+// can be found as a new task of the expected type.
+type Operation struct {
+	// ClusterID contains the cluster identifier.
+	ClusterID string
+
+	// Type represents the type of the spawned task.
+	Type Type
+
+	// NodeGroupID contains the nodegroup identifier of the spawned task. It can be empty.
+	NodeGroupID string
+
+	// PollInterval represents the interval between requests when waiting for the task.
+	// DefaultPollInterval is used if it's not set.
+	PollInterval time.Duration
+
+	// SnapshotAt represents the server time of the tasks list snapshot. It's read from
+	// the Date header of the snapshot response and is zero if the header is absent.
+	// Its precision is SnapshotPrecision.
+	SnapshotAt time.Time
+
+	// StartedAfter excludes tasks that have been started before the time even if they are
+	// absent in the snapshot. NewOperation sets it to SnapshotAt minus SnapshotPrecision,
+	// it's ignored if it's zero.
+	StartedAfter time.Time
+
+	// CompleteOnNotFound marks the operation as finished when the cluster tasks can't be found.
+	// It's used for operations that delete the cluster.
+	CompleteOnNotFound bool
+
+	client   *v1.ServiceClient
+	previous []*View
+	taskID   string
+}
+
+// NewOperation snapshots the cluster tasks list and returns an operation that tracks
+// a new task of the provided type. It should be called before the mutating request.
+// Tasks that have been started before the snapshot aren't matched to the operation.
+func NewOperation(ctx context.Context, client *v1.ServiceClient, clusterID string, taskType Type,
+	nodegroupID string,
+) (*Operation, error) {
+	previous, responseResult, err := List(ctx, client, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	op := &Operation{
+		ClusterID:   clusterID,
+		Type:        taskType,
+		NodeGroupID: nodegroupID,
+		client:      client,
+		previous:    previous,
+	}
+	if date, err := http.ParseTime(responseResult.Header.Get("Date")); err == nil {
+		op.SnapshotAt = date
+		op.StartedAfter = date.Add(-SnapshotPrecision)
+	}
+
+	return op, nil
+}
+
+// SetResponse reads the id of the spawned task from the response of the mutating request
+// if the response has the TaskIDHeader header.
+func (op *Operation) SetResponse(responseResult *v1.ResponseResult) {
+	if responseResult == nil || responseResult.Response == nil {
+		return
+	}
+	if taskID := responseResult.Header.Get(TaskIDHeader); taskID != "" {
+		op.taskID = taskID
+	}
+}
+
+// TaskID returns the id of the spawned task if it's already known.
+func (op *Operation) TaskID() string {
+	return op.taskID
+}
+
+// Task waits until the spawned task appears in the cluster tasks list and returns it.
+// If several new tasks match the operation, the earliest started one is returned.
+// Nil task is returned if CompleteOnNotFound is set and the cluster can't be found.
+func (op *Operation) Task(ctx context.Context) (*View, error) {
+	if op.taskID != "" {
+		clusterTask, responseResult, err := Get(ctx, op.client, op.ClusterID, op.taskID)
+
+		return op.checkNotFound(clusterTask, responseResult, err)
+	}
+
+	for {
+		current, responseResult, err := List(ctx, op.client, op.ClusterID)
+		if err != nil {
+			return op.checkNotFound(nil, responseResult, err)
+		}
+		if clusterTask := op.findSpawned(current, nil); clusterTask != nil {
+			op.taskID = clusterTask.ID
+
+			return clusterTask, nil
+		}
+
+		if err := v1.Sleep(ctx, op.pollInterval()); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Wait waits until the spawned task is finished and returns it.
+// ErrTaskFailed is returned along with the task if the task has finished with the error status.
+// Nil task is returned if CompleteOnNotFound is set and the cluster can't be found.
+func (op *Operation) Wait(ctx context.Context) (*View, error) {
+	clusterTask, err := op.Task(ctx)
+	if err != nil || clusterTask == nil {
+		return clusterTask, err
+	}
+
+	for {
+		clusterTask, responseResult, err := Get(ctx, op.client, op.ClusterID, op.taskID)
+		if err != nil {
+			return op.checkNotFound(nil, responseResult, err)
+		}
+		if clusterTask.Status.IsStable() {
+			return clusterTask, nil
+		}
+		if clusterTask.Status.IsError() {
+			return clusterTask, wrapTaskFailed(clusterTask)
+		}
+
+		if err := v1.Sleep(ctx, op.pollInterval()); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// WaitAll waits until the tasks of all operations are finished and returns them in the order
+// of the operations. Operations must belong to the same cluster, they are matched to distinct
+// tasks, so several operations of the same type and nodegroup can be waited at once.
+// ErrTaskFailed is returned along with the tasks as soon as any task has finished with the error
+// status. The poll interval of the first operation is used.
+func WaitAll(ctx context.Context, operations ...*Operation) ([]*View, error) {
+	if len(operations) == 0 {
+		return nil, nil
+	}
+
+	first := operations[0]
+	for {
+		current, _, err := List(ctx, first.client, first.ClusterID)
+		if err != nil {
+			return nil, err
+		}
+		tasks, finished, err := matchOperations(operations, current)
+		if err != nil || finished {
+			return tasks, err
+		}
+
+		if err := v1.Sleep(ctx, first.pollInterval()); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// matchOperations assigns spawned tasks to the operations and reports if all of them are finished.
+func matchOperations(operations []*Operation, current []*View) ([]*View, bool, error) {
+	claimed := make(map[string]struct{}, len(operations))
+	for _, op := range operations {
+		if op.taskID != "" {
+			claimed[op.taskID] = struct{}{}
+		}
+	}
+
+	tasks := make([]*View, len(operations))
+	finished := true
+	for i, op := range operations {
+		if op.taskID == "" {
+			if clusterTask := op.findSpawned(current, claimed); clusterTask != nil {
+				op.taskID = clusterTask.ID
+				claimed[clusterTask.ID] = struct{}{}
+			}
+		}
+		tasks[i] = findTask(current, op.taskID)
+		if tasks[i] == nil {
+			finished = false
+
+			continue
+		}
+		if tasks[i].Status.IsError() {
+			return tasks, true, wrapTaskFailed(tasks[i])
+		}
+		finished = finished && tasks[i].Status.IsStable()
+	}
+
+	return tasks, finished, nil
+}
+
+// findTask returns the task referenced by its id or nil if there is no such task.
+func findTask(tasks []*View, taskID string) *View {
+	if taskID == "" {
+		return nil
+	}
+	for _, clusterTask := range tasks {
+		if clusterTask.ID == taskID {
+			return clusterTask
+		}
+	}
+
+	return nil
+}
+
+// findSpawned returns the earliest started task that matches the operation, is absent
+// in the snapshot, hasn't been started before StartedAfter and isn't claimed.
+func (op *Operation) findSpawned(current []*View, claimed map[string]struct{}) *View {
+	var spawned *View
+	for len(current) > 0 {
+		clusterTask := FindNew(op.previous, current, op.Type, op.NodeGroupID)
+		if clusterTask == nil {
+			break
+		}
+		current = tasksAfter(current, clusterTask)
+		if _, ok := claimed[clusterTask.ID]; ok || op.startedBeforeSnapshot(clusterTask) {
+			continue
+		}
+		if spawned == nil || startedBefore(clusterTask, spawned) {
+			spawned = clusterTask
+		}
+	}
+
+	return spawned
+}
+
+// startedBeforeSnapshot returns true if the task has been started before StartedAfter.
+func (op *Operation) startedBeforeSnapshot(clusterTask *View) bool {
+	return !op.StartedAfter.IsZero() && clusterTask.StartedAt != nil && clusterTask.StartedAt.Before(op.StartedAfter)
+}
+
+// checkNotFound hides the not found error if the operation completes on it.
+func (op *Operation) checkNotFound(clusterTask *View, responseResult *v1.ResponseResult, err error) (*View, error) {
+	if err != nil && op.CompleteOnNotFound && responseResult != nil &&
+		responseResult.Response != nil && responseResult.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return clusterTask, nil
+}
+
+func (op *Operation) pollInterval() time.Duration {
+	if op.PollInterval <= 0 {
+		return DefaultPollInterval
+	}
+
+	return op.PollInterval
+}
+
+// startedBefore returns true if the first task has been started before the second one.
+// Tasks without the start time are treated as the latest ones.
+func startedBefore(a, b *View) bool {
+	if a.StartedAt == nil || b.StartedAt == nil {
+		return a.StartedAt != nil
+	}
+
+	return a.StartedAt.Before(*b.StartedAt)
+}
+
+// tasksAfter returns tasks placed after the provided task.
+func tasksAfter(tasks []*View, clusterTask *View) []*View {
+	for i := range tasks {
+		if tasks[i] == clusterTask {
+			return tasks[i+1:]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+package task
+
+import (
+	"strings"
+
+	v1 "github.com/selectel/mks-go/pkg/v1"
+	"github.com/selectel/mks-go/pkg/v1/internal/paging"
+)
+
+// Pager iterates over tasks of a cluster.
+type Pager = paging.Pager[*View]
+
+// NewPager returns a pager over tasks of a cluster that requests pages of the provided size.
+// All tasks are requested at once if the limit isn't positive.
+func NewPager(client *v1.ServiceClient, clusterID string, limit int) *Pager {
+	url := strings.Join([]string{client.Endpoint, v1.ResourceURLCluster, clusterID, v1.ResourceURLTask}, "/")
+
+	return paging.NewPager(client, url, "tasks", limit, func(clusterTask *View) string {
+		return clusterTask.ID
+	})
+}
@@ -53,3 +53,18 @@ func List(ctx context.Context, client *v1.ServiceClient, clusterID string) ([]*V
 
 	return result.Tasks, responseResult, err
 }
+
+// ListWithOpts gets a list of cluster tasks that match the provided options.
+// The MKS V1 API doesn't support filtering of tasks, so all tasks are retrieved
+// and the options are applied on the client side. All tasks are returned
+// if the options are nil.
+func ListWithOpts(ctx context.Context, client *v1.ServiceClient, clusterID string,
+	opts *ListOpts,
+) ([]*View, *v1.ResponseResult, error) {
+	tasks, responseResult, err := List(ctx, client, clusterID)
+	if err != nil {
+		return nil, responseResult, err
+	}
+
+	return Filter(tasks, opts), responseResult, nil
+}
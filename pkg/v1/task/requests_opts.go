@@ -0,0 +1,32 @@
+package task
+
+import "time"
+
+// ListOpts represents options for the task ListWithOpts request.
+// Empty fields are ignored.
+type ListOpts struct {
+	// Types contains allowed task types.
+	Types []Type
+
+	// Statuses contains allowed task statuses.
+	Statuses []Status
+
+	// NodeGroupID represents the nodegroup of tasks.
+	NodeGroupID string
+
+	// StartedAfter represents the time that tasks should be started at or after.
+	StartedAfter time.Time
+
+	// StartedBefore represents the time that tasks should be started before.
+	StartedBefore time.Time
+
+	// UpdatedAfter represents the time that tasks should be updated at or after.
+	UpdatedAfter time.Time
+
+	// UpdatedBefore represents the time that tasks should be updated before.
+	UpdatedBefore time.Time
+
+	// SortDescending sorts tasks from the newest to the oldest by the start time.
+	// Tasks are sorted from the oldest to the newest by default.
+	SortDescending bool
+}
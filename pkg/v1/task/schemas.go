@@ -3,6 +3,8 @@ package task
 import (
 	"encoding/json"
 	"time"
+
+	v1 "github.com/selectel/mks-go/pkg/v1"
 )
 
 // Status represents custom type for various task statuses.
@@ -77,6 +79,28 @@ func isTaskTypeSupported(t Type) bool {
 	return false
 }
 
+// parseStatus returns the status if it's supported or StatusUnknown otherwise.
+// Unsupported statuses are reported with v1.ReportUnknownValue.
+func parseStatus(raw string) Status {
+	if isSupportedStatus(Status(raw)) {
+		return Status(raw)
+	}
+	v1.ReportUnknownValue(v1.UnknownValueTaskStatus, raw)
+
+	return StatusUnknown
+}
+
+// parseType returns the task type if it's supported or TypeUnknown otherwise.
+// Unsupported types are reported with v1.ReportUnknownValue.
+func parseType(raw string) Type {
+	if isTaskTypeSupported(Type(raw)) {
+		return Type(raw)
+	}
+	v1.ReportUnknownValue(v1.UnknownValueTaskType, raw)
+
+	return TypeUnknown
+}
+
 // View represents an unmarshalled cluster task body from an API response.
 type View struct {
 	// ID is the identifier of the task.
@@ -94,19 +118,31 @@ type View struct {
 	// Status represents current status of the task.
 	Status Status `json:"-"`
 
+	// RawStatus contains the status as it was returned by the API.
+	// It's kept even if Status is StatusUnknown.
+	RawStatus string `json:"-"`
+
 	// Task represents task's type.
 	Type Type `json:"-"`
 
+	// RawType contains the task type as it was returned by the API.
+	// It's kept even if Type is TypeUnknown.
+	RawType string `json:"-"`
+
 	// NodeGroupID contains node group identifier. It can be empty.
 	NodeGroupID string `json:"nodegroup_id,omitempty"`
+
+	// Extra contains fields of the API response that are not modelled by the client.
+	// They are kept to be marshalled back.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 func (result *View) UnmarshalJSON(b []byte) error {
 	type tmp View
 	var s struct {
 		tmp
-		Status Status `json:"status"`
-		Type   Type   `json:"type"`
+		Status string `json:"status"`
+		Type   string `json:"type"`
 	}
 	if err := json.Unmarshal(b, &s); err != nil {
 		return err
@@ -115,18 +151,40 @@ func (result *View) UnmarshalJSON(b []byte) error {
 	*result = View(s.tmp)
 
 	// Check task status.
-	if isSupportedStatus(s.Status) {
-		result.Status = s.Status
-	} else {
-		result.Status = StatusUnknown
-	}
+	result.Status = parseStatus(s.Status)
+	result.RawStatus = s.Status
 
 	// Check task type.
-	if isTaskTypeSupported(s.Type) {
-		result.Type = s.Type
-	} else {
-		result.Type = TypeUnknown
+	result.Type = parseType(s.Type)
+	result.RawType = s.Type
+
+	extra, err := v1.ExtractExtraFields(b, result, "status", "type")
+	if err != nil {
+		return err
 	}
+	result.Extra = extra
 
 	return nil
 }
+
+// MarshalJSON marshals the task with its raw status, raw type and extra fields.
+func (result View) MarshalJSON() ([]byte, error) {
+	type tmp View
+	s := struct {
+		tmp
+		Status string `json:"status"`
+		Type   string `json:"type"`
+	}{
+		tmp:    tmp(result),
+		Status: result.RawStatus,
+		Type:   result.RawType,
+	}
+	if s.Status == "" {
+		s.Status = string(result.Status)
+	}
+	if s.Type == "" {
+		s.Type = string(result.Type)
+	}
+
+	return v1.MarshalWithExtraFields(s, result.Extra)
+}
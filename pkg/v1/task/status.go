@@ -0,0 +1,24 @@
+package task
+
+// Task statuses don't have a CanPerform method like cluster and nodegroup statuses
+// because the API doesn't provide operations on tasks, they can only be read.
+
+// IsStable returns true if the task has been finished successfully.
+func (s Status) IsStable() bool {
+	return s == StatusDone
+}
+
+// IsError returns true if the task has been finished with an error.
+func (s Status) IsError() bool {
+	return s == StatusError
+}
+
+// IsPending returns true if the task is in progress.
+func (s Status) IsPending() bool {
+	return s == StatusInProgress
+}
+
+// IsTerminal returns true if the task has been finished.
+func (s Status) IsTerminal() bool {
+	return s.IsStable() || s.IsError()
+}
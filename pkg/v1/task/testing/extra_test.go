@@ -0,0 +1,49 @@
+package testing
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	v1 "github.com/selectel/mks-go/pkg/v1"
+	"github.com/selectel/mks-go/pkg/v1/task"
+)
+
+func TestTaskExtraFieldsRoundTrip(t *testing.T) {
+	var actual task.View
+	if err := json.Unmarshal([]byte(testTaskExtraFieldsRaw), &actual); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedExtra := map[string]json.RawMessage{"progress": json.RawMessage(`42`)}
+	if !reflect.DeepEqual(expectedExtra, actual.Extra) {
+		t.Fatalf("expected %s extra fields, but got %s", expectedExtra, actual.Extra)
+	}
+	if actual.Type != task.TypeUnknown || actual.RawType != "MIGRATE_CLUSTER" {
+		t.Fatalf("expected UNKNOWN type with MIGRATE_CLUSTER raw type, but got %s and %s", actual.Type, actual.RawType)
+	}
+
+	marshalled, err := json.Marshal(&actual)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var roundTrip task.View
+	if err := json.Unmarshal(marshalled, &roundTrip); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(actual, roundTrip) {
+		t.Fatalf("expected %#v, but got %#v", actual, roundTrip)
+	}
+}
+
+func TestTaskStrictDecoding(t *testing.T) {
+	v1.SetStrictDecoding(true)
+	defer v1.SetStrictDecoding(false)
+
+	var actual task.View
+	err := json.Unmarshal([]byte(testTaskExtraFieldsRaw), &actual)
+	if !errors.Is(err, v1.ErrUnknownFields) {
+		t.Fatalf("expected ErrUnknownFields, but got %v", err)
+	}
+}
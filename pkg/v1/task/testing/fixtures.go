@@ -29,7 +29,9 @@ var expectedGetTaskResponse = &task.View{
 	UpdatedAt: &taskResponseTimestamp,
 	ClusterID: "d2e16a48-a9c5-4449-8b71-71f21fc872db",
 	Status:    task.StatusDone,
+	RawStatus: "DONE",
 	Type:      task.TypeCreateCluster,
+	RawType:   "CREATE_CLUSTER",
 }
 
 // testGetTaskUnknownStatusAndTypeResponseRaw represents a raw response from the Get request
@@ -55,7 +57,9 @@ var expectedGetTaskUnknownStatusAndTypeResponse = &task.View{
 	UpdatedAt: &taskResponseTimestamp,
 	ClusterID: "d2e16a48-a9c5-4449-8b71-71f21fc872dc",
 	Status:    task.StatusUnknown,
+	RawStatus: "FAKE_STATUS",
 	Type:      task.TypeUnknown,
+	RawType:   "FAKE_TYPE",
 }
 
 // testListTasksResponseRaw represents a raw response from the List method.
@@ -165,7 +169,9 @@ var expectedListTasksResponse = []*task.View{
 		UpdatedAt: &taskResponseTimestamp,
 		ClusterID: "d2e16a48-a9c5-4449-9b71-81f21fc872db",
 		Status:    task.StatusDone,
+		RawStatus: "DONE",
 		Type:      task.TypeCreateCluster,
+		RawType:   "CREATE_CLUSTER",
 	},
 	{
 		ID:        "3f8fb93c-cf9e-4289-a78c-34393ac75f92",
@@ -173,7 +179,9 @@ var expectedListTasksResponse = []*task.View{
 		UpdatedAt: &taskResponseTimestamp,
 		ClusterID: "d2e16a48-a9c5-4449-9b71-81f21fc872db",
 		Status:    task.StatusDone,
+		RawStatus: "DONE",
 		Type:      task.TypeRotateCerts,
+		RawType:   "ROTATE_CERTS",
 	},
 	{
 		ID:          "4f8fb93c-cf9e-4289-a78c-34393ac75f92",
@@ -181,7 +189,9 @@ var expectedListTasksResponse = []*task.View{
 		UpdatedAt:   &taskResponseTimestamp,
 		ClusterID:   "d2e16a48-a9c5-4449-9b71-81f21fc872db",
 		Status:      task.StatusDone,
+		RawStatus:   "DONE",
 		Type:        task.TypeNodeGroupResize,
+		RawType:     "NODE_GROUP_RESIZE",
 		NodeGroupID: "9e714bc6-3815-4af5-9c94-f1560e87641a",
 	},
 	{
@@ -190,7 +200,9 @@ var expectedListTasksResponse = []*task.View{
 		UpdatedAt:   &taskResponseTimestamp,
 		ClusterID:   "d2e16a48-a9c5-4449-9b71-81f21fc872db",
 		Status:      task.StatusDone,
+		RawStatus:   "DONE",
 		Type:        task.TypeNodeReinstall,
+		RawType:     "NODE_REINSTALL",
 		NodeGroupID: "9e714bc6-3815-4af5-9c94-f1560e87641a",
 	},
 	{
@@ -199,7 +211,9 @@ var expectedListTasksResponse = []*task.View{
 		UpdatedAt: &taskResponseTimestamp,
 		ClusterID: "d2e16a48-a9c5-4449-9b71-81f21fc872db",
 		Status:    task.StatusDone,
+		RawStatus: "DONE",
 		Type:      task.TypeClusterResize,
+		RawType:   "CLUSTER_RESIZE",
 	},
 	{
 		ID:        "7f8fb93c-cf9e-4289-a78c-34393ac75f92",
@@ -207,7 +221,9 @@ var expectedListTasksResponse = []*task.View{
 		UpdatedAt: &taskResponseTimestamp,
 		ClusterID: "d2e16a48-a9c5-4449-9b71-81f21fc872db",
 		Status:    task.StatusError,
+		RawStatus: "ERROR",
 		Type:      task.TypeUpgradePatchVersion,
+		RawType:   "UPGRADE_PATCH_VERSION",
 	},
 	{
 		ID:        "7f8fb93c-cf9e-4289-a78c-34393ac75f92",
@@ -215,7 +231,9 @@ var expectedListTasksResponse = []*task.View{
 		UpdatedAt: &taskResponseTimestamp,
 		ClusterID: "d2e16a48-a9c5-4449-9b71-81f21fc872db",
 		Status:    task.StatusInProgress,
+		RawStatus: "IN_PROGRESS",
 		Type:      task.TypeUpgradeMinorVersion,
+		RawType:   "UPGRADE_MINOR_VERSION",
 	},
 	{
 		ID:          "7f8fb93c-cf9e-4289-a78c-34393ac75f92",
@@ -223,7 +241,9 @@ var expectedListTasksResponse = []*task.View{
 		UpdatedAt:   &taskResponseTimestamp,
 		ClusterID:   "d2e16a48-a9c5-4449-9b71-81f21fc872db",
 		Status:      task.StatusInProgress,
+		RawStatus:   "IN_PROGRESS",
 		Type:        task.TypeUpdateNodegroupLabels,
+		RawType:     "UPDATE_NODEGROUP_LABELS",
 		NodeGroupID: "9e714bc6-3815-4af5-9c94-f1560e87641a",
 	},
 	{
@@ -232,7 +252,9 @@ var expectedListTasksResponse = []*task.View{
 		UpdatedAt: &taskResponseTimestamp,
 		ClusterID: "d2e16a48-a9c5-4449-9b71-81f21fc872db",
 		Status:    task.StatusInProgress,
+		RawStatus: "IN_PROGRESS",
 		Type:      task.TypeUpgradeMastersConfiguration,
+		RawType:   "UPGRADE_MASTERS_CONFIGURATION",
 	},
 	{
 		ID:        "7f8fb93c-cf9e-4289-a78c-34393ac75f92",
@@ -240,7 +262,9 @@ var expectedListTasksResponse = []*task.View{
 		UpdatedAt: &taskResponseTimestamp,
 		ClusterID: "d2e16a48-a9c5-4449-9b71-81f21fc872db",
 		Status:    task.StatusInProgress,
+		RawStatus: "IN_PROGRESS",
 		Type:      task.TypeUpgradeClusterConfiguration,
+		RawType:   "UPGRADE_CLUSTER_CONFIGURATION",
 	},
 	{
 		ID:        "8f8fb93c-cf9e-4289-a78c-34393ac75f92",
@@ -248,7 +272,9 @@ var expectedListTasksResponse = []*task.View{
 		UpdatedAt: &taskResponseTimestamp,
 		ClusterID: "d2e16a48-a9c5-4449-9b71-81f21fc872db",
 		Status:    task.StatusInProgress,
+		RawStatus: "IN_PROGRESS",
 		Type:      task.TypeDeleteCluster,
+		RawType:   "DELETE_CLUSTER",
 	},
 }
 
@@ -278,7 +304,9 @@ var expectedListTasksUnknownStatusAndTypeResponse = []*task.View{
 		UpdatedAt: &taskResponseTimestamp,
 		ClusterID: "d1e16a48-a9c5-4449-9b71-81f21fc872cb",
 		Status:    task.StatusUnknown,
+		RawStatus: "FAKE_STATUS",
 		Type:      task.TypeUnknown,
+		RawType:   "FAKE_TYPE",
 	},
 }
 
@@ -304,3 +332,85 @@ const testSingleTaskInvalidResponseRaw = `
 
 // testErrGenericResponseRaw represents a raw response with an error in the generic format.
 const testErrGenericResponseRaw = `{"error":{"message":"bad gateway"}}`
+
+// testGetFailedTaskResponseRaw represents a raw response from the Get request with a failed task.
+const testGetFailedTaskResponseRaw = `
+{
+    "task": {
+        "cluster_id": "d2e16a48-a9c5-4449-8b71-71f21fc872db",
+        "id": "7f8fb93c-cf9e-4289-a78c-34393ac75f92",
+        "started_at": "2020-02-19T11:43:02.868387Z",
+        "status": "ERROR",
+        "type": "UPGRADE_PATCH_VERSION",
+        "updated_at": "2020-02-19T11:43:02.868387Z"
+    }
+}
+`
+
+// testGetInProgressTaskResponseRaw represents a raw response from the Get request with a running task.
+const testGetInProgressTaskResponseRaw = `
+{
+    "task": {
+        "cluster_id": "d2e16a48-a9c5-4449-8b71-71f21fc872db",
+        "id": "8f8fb93c-cf9e-4289-a78c-34393ac75f92",
+        "started_at": "2020-02-19T11:43:02.868387Z",
+        "status": "IN_PROGRESS",
+        "type": "DELETE_CLUSTER",
+        "updated_at": "2020-02-19T11:43:02.868387Z"
+    }
+}
+`
+
+// testTaskExtraFieldsRaw represents a raw task with fields that are not modelled by the client.
+const testTaskExtraFieldsRaw = `
+{
+    "id": "2f6fb93c-cf0d-4289-a78c-34393ac75f92",
+    "cluster_id": "d2e16a48-a9c5-4449-8b71-71f21fc872db",
+    "status": "IN_PROGRESS",
+    "type": "MIGRATE_CLUSTER",
+    "progress": 42
+}
+`
+
+// testTaskHistoryResponseRaw represents a raw response from the List request
+// with tasks that have different timestamps.
+const testTaskHistoryResponseRaw = `
+{
+    "tasks": [
+        {
+            "cluster_id": "d2e16a48-a9c5-4449-8b71-71f21fc872db",
+            "id": "task-resize-2",
+            "started_at": "2023-05-02T10:00:00Z",
+            "updated_at": "2023-05-02T10:20:00Z",
+            "status": "DONE",
+            "type": "NODE_GROUP_RESIZE",
+            "nodegroup_id": "9e714bc6-3815-4af5-9c94-f1560e87641a"
+        },
+        {
+            "cluster_id": "d2e16a48-a9c5-4449-8b71-71f21fc872db",
+            "id": "task-create",
+            "started_at": "2023-05-01T09:00:00Z",
+            "updated_at": "2023-05-01T09:15:00Z",
+            "status": "DONE",
+            "type": "CREATE_CLUSTER"
+        },
+        {
+            "cluster_id": "d2e16a48-a9c5-4449-8b71-71f21fc872db",
+            "id": "task-upgrade",
+            "started_at": "2023-05-03T08:00:00Z",
+            "updated_at": "2023-05-03T08:05:00Z",
+            "status": "IN_PROGRESS",
+            "type": "UPGRADE_PATCH_VERSION"
+        },
+        {
+            "cluster_id": "d2e16a48-a9c5-4449-8b71-71f21fc872db",
+            "id": "task-resize-1",
+            "started_at": "2023-05-01T12:00:00Z",
+            "updated_at": "2023-05-01T12:30:00Z",
+            "status": "ERROR",
+            "type": "NODE_GROUP_RESIZE",
+            "nodegroup_id": "1c0e8a57-4a3f-4b53-8a3b-7c0d4e7a2f11"
+        }
+    ]
+}
+`
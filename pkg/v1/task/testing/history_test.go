@@ -0,0 +1,173 @@
+package testing
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/selectel/mks-go/pkg/testutils"
+	v1 "github.com/selectel/mks-go/pkg/v1"
+	"github.com/selectel/mks-go/pkg/v1/task"
+)
+
+const historyClusterID = "d2e16a48-a9c5-4449-8b71-71f21fc872db"
+
+// newTaskHistoryTestClient registers the task history handler and returns a client for it.
+func newTaskHistoryTestClient(t *testing.T, testEnv *testutils.TestEnv, endpointCalled *bool) *v1.ServiceClient {
+	testutils.HandleReqWithoutBody(t, &testutils.HandleReqOpts{
+		Mux:         testEnv.Mux,
+		URL:         "/v1/clusters/" + historyClusterID + "/tasks",
+		RawResponse: testTaskHistoryResponseRaw,
+		Method:      http.MethodGet,
+		Status:      http.StatusOK,
+		CallFlag:    endpointCalled,
+	})
+
+	return &v1.ServiceClient{
+		HTTPClient: &http.Client{},
+		TokenID:    testutils.TokenID,
+		Endpoint:   testEnv.Server.URL + "/v1",
+		UserAgent:  testutils.UserAgent,
+	}
+}
+
+func taskIDs(tasks []*task.View) []string {
+	ids := make([]string, 0, len(tasks))
+	for _, clusterTask := range tasks {
+		ids = append(ids, clusterTask.ID)
+	}
+
+	return ids
+}
+
+func TestListTasksWithOpts(t *testing.T) {
+	testCases := []struct {
+		name     string
+		opts     *task.ListOpts
+		expected []string
+	}{
+		{
+			name:     "sorted by start time",
+			opts:     &task.ListOpts{},
+			expected: []string{"task-create", "task-resize-1", "task-resize-2", "task-upgrade"},
+		},
+		{
+			name:     "nil options",
+			opts:     nil,
+			expected: []string{"task-create", "task-resize-1", "task-resize-2", "task-upgrade"},
+		},
+		{
+			name:     "sorted by start time descending",
+			opts:     &task.ListOpts{SortDescending: true},
+			expected: []string{"task-upgrade", "task-resize-2", "task-resize-1", "task-create"},
+		},
+		{
+			name:     "type",
+			opts:     &task.ListOpts{Types: []task.Type{task.TypeNodeGroupResize}},
+			expected: []string{"task-resize-1", "task-resize-2"},
+		},
+		{
+			name:     "statuses",
+			opts:     &task.ListOpts{Statuses: []task.Status{task.StatusError, task.StatusInProgress}},
+			expected: []string{"task-resize-1", "task-upgrade"},
+		},
+		{
+			name:     "nodegroup",
+			opts:     &task.ListOpts{NodeGroupID: "9e714bc6-3815-4af5-9c94-f1560e87641a"},
+			expected: []string{"task-resize-2"},
+		},
+		{
+			name: "started time range",
+			opts: &task.ListOpts{
+				StartedAfter:  time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC),
+				StartedBefore: time.Date(2023, 5, 3, 8, 0, 0, 0, time.UTC),
+			},
+			expected: []string{"task-resize-1", "task-resize-2"},
+		},
+		{
+			name:     "updated after",
+			opts:     &task.ListOpts{UpdatedAfter: time.Date(2023, 5, 2, 10, 20, 0, 0, time.UTC)},
+			expected: []string{"task-resize-2", "task-upgrade"},
+		},
+	}
+
+	for _, tc := range testCases {
+		endpointCalled := false
+		testEnv := testutils.SetupTestEnv()
+		testClient := newTaskHistoryTestClient(t, testEnv, &endpointCalled)
+
+		actual, _, err := task.ListWithOpts(context.Background(), testClient, historyClusterID, tc.opts)
+		testEnv.TearDownTestEnv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !endpointCalled {
+			t.Fatal("endpoint wasn't called")
+		}
+		if !reflect.DeepEqual(tc.expected, taskIDs(actual)) {
+			t.Fatalf("%s: expected %v, but got %v", tc.name, tc.expected, taskIDs(actual))
+		}
+	}
+}
+
+func TestLatestTask(t *testing.T) {
+	endpointCalled := false
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	testClient := newTaskHistoryTestClient(t, testEnv, &endpointCalled)
+	ctx := context.Background()
+
+	actual, _, err := task.Latest(ctx, testClient, historyClusterID, task.TypeNodeGroupResize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual == nil || actual.ID != "task-resize-2" {
+		t.Fatalf("expected task-resize-2 task, but got %#v", actual)
+	}
+
+	actual, _, err = task.Latest(ctx, testClient, historyClusterID, task.TypeRotateCerts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual != nil {
+		t.Fatalf("expected no task, but got %#v", actual)
+	}
+}
+
+func TestInProgressTasks(t *testing.T) {
+	endpointCalled := false
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	testClient := newTaskHistoryTestClient(t, testEnv, &endpointCalled)
+
+	actual, _, err := task.InProgress(context.Background(), testClient, historyClusterID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual([]string{"task-upgrade"}, taskIDs(actual)) {
+		t.Fatalf("expected [task-upgrade], but got %v", taskIDs(actual))
+	}
+}
+
+func TestTaskDurations(t *testing.T) {
+	endpointCalled := false
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	testClient := newTaskHistoryTestClient(t, testEnv, &endpointCalled)
+
+	tasks, _, err := task.List(context.Background(), testClient, historyClusterID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]time.Duration{
+		"task-create":   15 * time.Minute,
+		"task-resize-1": 30 * time.Minute,
+		"task-resize-2": 20 * time.Minute,
+	}
+	if actual := task.Durations(tasks); !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected %v, but got %v", expected, actual)
+	}
+}
@@ -0,0 +1,216 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/selectel/mks-go/pkg/testutils"
+	v1 "github.com/selectel/mks-go/pkg/v1"
+	"github.com/selectel/mks-go/pkg/v1/task"
+)
+
+const (
+	operationClusterID   = "4c2d6a3e-4b1e-4f8a-9c7e-2a6d1b0f8e11"
+	operationNodegroupID = "9e714bc6-3815-4af5-9c94-f1560e87641a"
+)
+
+func newOperationTestClient(testEnv *testutils.TestEnv) *v1.ServiceClient {
+	return &v1.ServiceClient{
+		HTTPClient: &http.Client{},
+		TokenID:    testutils.TokenID,
+		Endpoint:   testEnv.Server.URL + "/v1",
+		UserAgent:  testutils.UserAgent,
+	}
+}
+
+func TestOperationFindsSpawnedTask(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	fakeTasks := testutils.HandleFakeTasks(testEnv.Mux, operationClusterID)
+	fakeTasks.Add("resize-old", string(task.TypeNodeGroupResize), string(task.StatusDone), operationNodegroupID)
+
+	ctx := context.Background()
+	testClient := newOperationTestClient(testEnv)
+
+	operation, err := task.NewOperation(ctx, testClient, operationClusterID, task.TypeNodeGroupResize, operationNodegroupID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	operation.PollInterval = time.Millisecond
+
+	// Tasks spawned after the snapshot: only the earliest matching one belongs to the operation.
+	fakeTasks.Add("rotate", string(task.TypeRotateCerts), string(task.StatusInProgress), "")
+	fakeTasks.Add("resize-other", string(task.TypeNodeGroupResize), string(task.StatusInProgress), "other")
+	late := fakeTasks.Add("resize-late", string(task.TypeNodeGroupResize), string(task.StatusInProgress), operationNodegroupID)
+	lateStart := late.StartedAt.Add(time.Minute)
+	late.StartedAt = &lateStart
+	fakeTasks.Add("resize-spawned", string(task.TypeNodeGroupResize), string(task.StatusDone), operationNodegroupID)
+
+	actual, err := operation.Wait(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual.ID != "resize-spawned" || operation.TaskID() != "resize-spawned" {
+		t.Fatalf("expected resize-spawned task, but got %s", actual.ID)
+	}
+}
+
+func TestOperationTaskIDHeader(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	fakeTasks := testutils.HandleFakeTasks(testEnv.Mux, operationClusterID)
+
+	ctx := context.Background()
+	testClient := newOperationTestClient(testEnv)
+
+	operation, err := task.NewOperation(ctx, testClient, operationClusterID, task.TypeRotateCerts, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fakeTasks.Add("rotate-1", string(task.TypeRotateCerts), string(task.StatusDone), "")
+	fakeTasks.Add("rotate-2", string(task.TypeRotateCerts), string(task.StatusDone), "")
+
+	header := http.Header{}
+	header.Set(task.TaskIDHeader, "rotate-2")
+	operation.SetResponse(&v1.ResponseResult{Response: &http.Response{Header: header}})
+
+	actual, err := operation.Task(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual.ID != "rotate-2" {
+		t.Fatalf("expected rotate-2 task from the header, but got %s", actual.ID)
+	}
+}
+
+func TestOperationWaitFailed(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	fakeTasks := testutils.HandleFakeTasks(testEnv.Mux, operationClusterID)
+
+	ctx := context.Background()
+	testClient := newOperationTestClient(testEnv)
+
+	operation, err := task.NewOperation(ctx, testClient, operationClusterID, task.TypeNodeReinstall, operationNodegroupID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	operation.PollInterval = time.Millisecond
+	fakeTasks.Add("reinstall", string(task.TypeNodeReinstall), string(task.StatusError), operationNodegroupID)
+
+	actual, err := operation.Wait(ctx)
+	if !errors.Is(err, task.ErrTaskFailed) {
+		t.Fatalf("expected ErrTaskFailed, but got %v", err)
+	}
+	if actual == nil || actual.ID != "reinstall" {
+		t.Fatalf("expected the failed task, but got %#v", actual)
+	}
+}
+
+func TestOperationCompleteOnNotFound(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	fakeTasks := testutils.HandleFakeTasks(testEnv.Mux, operationClusterID)
+
+	ctx := context.Background()
+	testClient := newOperationTestClient(testEnv)
+
+	operation, err := task.NewOperation(ctx, testClient, operationClusterID, task.TypeDeleteCluster, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	operation.PollInterval = time.Millisecond
+	fakeTasks.SetNotFound(true)
+
+	if _, err := operation.Wait(ctx); err == nil {
+		t.Fatal("expected not found error without CompleteOnNotFound")
+	}
+
+	operation.CompleteOnNotFound = true
+	actual, err := operation.Wait(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual != nil {
+		t.Fatalf("expected no task for the deleted cluster, but got %#v", actual)
+	}
+}
+
+func TestWaitAll(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	fakeTasks := testutils.HandleFakeTasks(testEnv.Mux, operationClusterID)
+
+	ctx := context.Background()
+	testClient := newOperationTestClient(testEnv)
+
+	var operations []*task.Operation
+	for i := 0; i < 2; i++ {
+		operation, err := task.NewOperation(ctx, testClient, operationClusterID, task.TypeNodeReinstall, operationNodegroupID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if operation.SnapshotAt.IsZero() {
+			t.Fatal("expected the snapshot time to be read from the Date header")
+		}
+		operation.PollInterval = time.Millisecond
+		operations = append(operations, operation)
+	}
+
+	// A task that has been started before the snapshot isn't matched.
+	autorepair := fakeTasks.Add("reinstall-autorepair", string(task.TypeNodeReinstall), string(task.StatusInProgress),
+		operationNodegroupID)
+	autorepairStart := operations[0].SnapshotAt.Add(-time.Minute)
+	autorepair.StartedAt = &autorepairStart
+	fakeTasks.Add("reinstall-1", string(task.TypeNodeReinstall), string(task.StatusInProgress), operationNodegroupID)
+	fakeTasks.Add("reinstall-2", string(task.TypeNodeReinstall), string(task.StatusInProgress), operationNodegroupID)
+	time.AfterFunc(10*time.Millisecond, func() {
+		fakeTasks.SetStatus("reinstall-1", string(task.StatusDone))
+		fakeTasks.SetStatus("reinstall-2", string(task.StatusDone))
+	})
+
+	tasks, err := task.WaitAll(ctx, operations...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 2 || tasks[0].ID != "reinstall-1" || tasks[1].ID != "reinstall-2" {
+		t.Fatalf("expected reinstall-1 and reinstall-2 tasks, but got %v", tasks)
+	}
+	for _, clusterTask := range tasks {
+		if clusterTask.Status != task.StatusDone {
+			t.Fatalf("expected %s task to be done, but got %s", clusterTask.ID, clusterTask.Status)
+		}
+	}
+}
+
+func TestWaitAllFailed(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	fakeTasks := testutils.HandleFakeTasks(testEnv.Mux, operationClusterID)
+
+	ctx := context.Background()
+	testClient := newOperationTestClient(testEnv)
+
+	var operations []*task.Operation
+	for i := 0; i < 2; i++ {
+		operation, err := task.NewOperation(ctx, testClient, operationClusterID, task.TypeNodeReinstall, operationNodegroupID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		operation.PollInterval = time.Millisecond
+		operations = append(operations, operation)
+	}
+	fakeTasks.Add("reinstall-1", string(task.TypeNodeReinstall), string(task.StatusInProgress), operationNodegroupID)
+	fakeTasks.Add("reinstall-2", string(task.TypeNodeReinstall), string(task.StatusError), operationNodegroupID)
+
+	tasks, err := task.WaitAll(ctx, operations...)
+	if !errors.Is(err, task.ErrTaskFailed) {
+		t.Fatalf("expected ErrTaskFailed, but got %v", err)
+	}
+	if len(tasks) != 2 || tasks[1] == nil || tasks[1].ID != "reinstall-2" {
+		t.Fatalf("expected the failed task to be returned, but got %v", tasks)
+	}
+}
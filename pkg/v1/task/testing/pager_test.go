@@ -0,0 +1,50 @@
+package testing
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/selectel/mks-go/pkg/testutils"
+	v1 "github.com/selectel/mks-go/pkg/v1"
+	"github.com/selectel/mks-go/pkg/v1/task"
+)
+
+func TestTaskPager(t *testing.T) {
+	endpointCalled := false
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	testutils.HandleReqWithoutBody(t, &testutils.HandleReqOpts{
+		Mux:         testEnv.Mux,
+		URL:         "/v1/clusters/d2e16a48-a9c5-4449-8b71-71f21fc872db/tasks",
+		RawResponse: testListTasksResponseRaw,
+		Method:      http.MethodGet,
+		Status:      http.StatusOK,
+		CallFlag:    &endpointCalled,
+	})
+
+	ctx := context.Background()
+	testClient := &v1.ServiceClient{
+		HTTPClient: &http.Client{},
+		TokenID:    testutils.TokenID,
+		Endpoint:   testEnv.Server.URL + "/v1",
+		UserAgent:  testutils.UserAgent,
+	}
+
+	pager := task.NewPager(testClient, "d2e16a48-a9c5-4449-8b71-71f21fc872db", 0)
+	var actual []*task.View
+	for pager.Next(ctx) {
+		actual = append(actual, pager.Current())
+	}
+	if err := pager.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if !endpointCalled {
+		t.Fatal("endpoint wasn't called")
+	}
+	if !reflect.DeepEqual(expectedListTasksResponse, actual) {
+		t.Fatalf("expected %#v, but got %#v", expectedListTasksResponse, actual)
+	}
+}
@@ -0,0 +1,36 @@
+package testing
+
+import (
+	"testing"
+
+	"github.com/selectel/mks-go/pkg/v1/task"
+)
+
+func TestStatusClassification(t *testing.T) {
+	testCases := []struct {
+		status   task.Status
+		stable   bool
+		isError  bool
+		pending  bool
+		terminal bool
+	}{
+		{status: task.StatusDone, stable: true, terminal: true},
+		{status: task.StatusError, isError: true, terminal: true},
+		{status: task.StatusInProgress, pending: true},
+		{status: task.StatusUnknown},
+	}
+	for _, tc := range testCases {
+		if tc.status.IsStable() != tc.stable {
+			t.Fatalf("expected IsStable of %s to be %v", tc.status, tc.stable)
+		}
+		if tc.status.IsError() != tc.isError {
+			t.Fatalf("expected IsError of %s to be %v", tc.status, tc.isError)
+		}
+		if tc.status.IsPending() != tc.pending {
+			t.Fatalf("expected IsPending of %s to be %v", tc.status, tc.pending)
+		}
+		if tc.status.IsTerminal() != tc.terminal {
+			t.Fatalf("expected IsTerminal of %s to be %v", tc.status, tc.terminal)
+		}
+	}
+}
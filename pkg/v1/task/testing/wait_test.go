@@ -0,0 +1,143 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/selectel/mks-go/pkg/testutils"
+	v1 "github.com/selectel/mks-go/pkg/v1"
+	"github.com/selectel/mks-go/pkg/v1/task"
+)
+
+func TestFindNewTask(t *testing.T) {
+	previous := expectedListTasksResponse[:3]
+
+	actual := task.FindNew(previous, expectedListTasksResponse, task.TypeNodeReinstall, "")
+	if !reflect.DeepEqual(expectedListTasksResponse[3], actual) {
+		t.Fatalf("expected %#v, but got %#v", expectedListTasksResponse[3], actual)
+	}
+
+	actual = task.FindNew(previous, expectedListTasksResponse, task.TypeNodeGroupResize, "")
+	if actual != nil {
+		t.Fatalf("expected no new task, but got %#v", actual)
+	}
+
+	actual = task.FindNew(previous, expectedListTasksResponse, task.TypeUpdateNodegroupLabels,
+		"9e714bc6-3815-4af5-9c94-f1560e87641a")
+	if !reflect.DeepEqual(expectedListTasksResponse[7], actual) {
+		t.Fatalf("expected %#v, but got %#v", expectedListTasksResponse[7], actual)
+	}
+}
+
+func TestWaitTask(t *testing.T) {
+	endpointCalled := false
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	testutils.HandleReqWithoutBody(t, &testutils.HandleReqOpts{
+		Mux:         testEnv.Mux,
+		URL:         "/v1/clusters/d2e16a48-a9c5-4449-8b71-71f21fc872db/tasks/2f6fb93c-cf0d-4289-a78c-34393ac75f92",
+		RawResponse: testGetTaskResponseRaw,
+		Method:      http.MethodGet,
+		Status:      http.StatusOK,
+		CallFlag:    &endpointCalled,
+	})
+
+	ctx := context.Background()
+	testClient := &v1.ServiceClient{
+		HTTPClient: &http.Client{},
+		TokenID:    testutils.TokenID,
+		Endpoint:   testEnv.Server.URL + "/v1",
+		UserAgent:  testutils.UserAgent,
+	}
+	clusterID := "d2e16a48-a9c5-4449-8b71-71f21fc872db"
+	taskID := "2f6fb93c-cf0d-4289-a78c-34393ac75f92"
+
+	actual, err := task.Wait(ctx, testClient, clusterID, taskID, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !endpointCalled {
+		t.Fatal("endpoint wasn't called")
+	}
+	if !reflect.DeepEqual(expectedGetTaskResponse, actual) {
+		t.Fatalf("expected %#v, but got %#v", expectedGetTaskResponse, actual)
+	}
+}
+
+func TestWaitTaskFailed(t *testing.T) {
+	endpointCalled := false
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	testutils.HandleReqWithoutBody(t, &testutils.HandleReqOpts{
+		Mux:         testEnv.Mux,
+		URL:         "/v1/clusters/d2e16a48-a9c5-4449-8b71-71f21fc872db/tasks/7f8fb93c-cf9e-4289-a78c-34393ac75f92",
+		RawResponse: testGetFailedTaskResponseRaw,
+		Method:      http.MethodGet,
+		Status:      http.StatusOK,
+		CallFlag:    &endpointCalled,
+	})
+
+	ctx := context.Background()
+	testClient := &v1.ServiceClient{
+		HTTPClient: &http.Client{},
+		TokenID:    testutils.TokenID,
+		Endpoint:   testEnv.Server.URL + "/v1",
+		UserAgent:  testutils.UserAgent,
+	}
+	clusterID := "d2e16a48-a9c5-4449-8b71-71f21fc872db"
+	taskID := "7f8fb93c-cf9e-4289-a78c-34393ac75f92"
+
+	actual, err := task.Wait(ctx, testClient, clusterID, taskID, time.Millisecond)
+	if !errors.Is(err, task.ErrTaskFailed) {
+		t.Fatalf("expected ErrTaskFailed, but got %v", err)
+	}
+	if !endpointCalled {
+		t.Fatal("endpoint wasn't called")
+	}
+	if actual == nil || actual.Status != task.StatusError {
+		t.Fatalf("expected task with the error status, but got %#v", actual)
+	}
+}
+
+func TestWaitTaskCancelledContext(t *testing.T) {
+	endpointCalled := false
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+
+	testutils.HandleReqWithoutBody(t, &testutils.HandleReqOpts{
+		Mux:         testEnv.Mux,
+		URL:         "/v1/clusters/d2e16a48-a9c5-4449-8b71-71f21fc872db/tasks/8f8fb93c-cf9e-4289-a78c-34393ac75f92",
+		RawResponse: testGetInProgressTaskResponseRaw,
+		Method:      http.MethodGet,
+		Status:      http.StatusOK,
+		CallFlag:    &endpointCalled,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	testClient := &v1.ServiceClient{
+		HTTPClient: &http.Client{},
+		TokenID:    testutils.TokenID,
+		Endpoint:   testEnv.Server.URL + "/v1",
+		UserAgent:  testutils.UserAgent,
+	}
+	clusterID := "d2e16a48-a9c5-4449-8b71-71f21fc872db"
+	taskID := "8f8fb93c-cf9e-4289-a78c-34393ac75f92"
+
+	actual, err := task.Wait(ctx, testClient, clusterID, taskID, time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, but got %v", err)
+	}
+	if !endpointCalled {
+		t.Fatal("endpoint wasn't called")
+	}
+	if actual != nil {
+		t.Fatalf("expected no task from the Wait method, but got %#v", actual)
+	}
+}
@@ -0,0 +1,107 @@
+package testing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/selectel/mks-go/pkg/testutils"
+	"github.com/selectel/mks-go/pkg/v1/task"
+)
+
+// nextEvent returns the next watch event or fails the test after the timeout.
+func nextEvent(t *testing.T, events <-chan task.Event) task.Event {
+	t.Helper()
+
+	select {
+	case event, ok := <-events:
+		if !ok {
+			t.Fatal("events channel has been closed")
+		}
+
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("no watch event has been received")
+	}
+
+	return task.Event{}
+}
+
+func TestWatch(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	fakeTasks := testutils.HandleFakeTasks(testEnv.Mux, operationClusterID)
+	fakeTasks.Add("existing", string(task.TypeNodeGroupResize), string(task.StatusInProgress), operationNodegroupID)
+	fakeTasks.Add("finished", string(task.TypeCreateCluster), string(task.StatusDone), "")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	testClient := newOperationTestClient(testEnv)
+
+	events := task.Watch(ctx, testClient, operationClusterID, &task.WatchOpts{
+		MinInterval:     time.Millisecond,
+		MaxInterval:     4 * time.Millisecond,
+		IncludeExisting: true,
+	})
+
+	event := nextEvent(t, events)
+	if event.Type != task.EventTaskStarted || event.Task.ID != "existing" {
+		t.Fatalf("expected started event of the existing task, but got %s %#v", event.Type, event.Task)
+	}
+
+	fakeTasks.Add("rotate", string(task.TypeRotateCerts), string(task.StatusInProgress), "")
+	event = nextEvent(t, events)
+	if event.Type != task.EventTaskStarted || event.Task.ID != "rotate" {
+		t.Fatalf("expected started event of the rotate task, but got %s %#v", event.Type, event.Task)
+	}
+
+	fakeTasks.SetStatus("rotate", string(task.StatusDone))
+	event = nextEvent(t, events)
+	if event.Type != task.EventTaskFinished || event.Task.ID != "rotate" || event.PreviousStatus != task.StatusInProgress {
+		t.Fatalf("expected finished event of the rotate task, but got %s %#v", event.Type, event.Task)
+	}
+
+	// Errors are reported and watching is resumed.
+	fakeTasks.SetNotFound(true)
+	event = nextEvent(t, events)
+	if event.Type != task.EventError || event.Err == nil {
+		t.Fatalf("expected error event, but got %s", event.Type)
+	}
+	for event.Type == task.EventError {
+		fakeTasks.SetNotFound(false)
+		fakeTasks.SetStatus("existing", string(task.StatusError))
+		event = nextEvent(t, events)
+	}
+	if event.Type != task.EventTaskFinished || event.Task.ID != "existing" || event.Task.Status != task.StatusError {
+		t.Fatalf("expected finished event of the existing task, but got %s %#v", event.Type, event.Task)
+	}
+
+	// The channel is closed after the context cancellation.
+	cancel()
+	for {
+		if _, ok := <-events; !ok {
+			break
+		}
+	}
+}
+
+func TestWatchCancelledContext(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	testutils.HandleFakeTasks(testEnv.Mux, operationClusterID)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	testClient := newOperationTestClient(testEnv)
+
+	events := task.Watch(ctx, testClient, operationClusterID, &task.WatchOpts{MinInterval: time.Millisecond})
+	cancel()
+
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Fatal("expected no events after the context cancellation")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("events channel hasn't been closed")
+	}
+}
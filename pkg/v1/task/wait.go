@@ -0,0 +1,99 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	v1 "github.com/selectel/mks-go/pkg/v1"
+)
+
+// DefaultPollInterval represents the default interval between requests when waiting for tasks.
+const DefaultPollInterval = 10 * time.Second
+
+// ErrTaskFailed is returned when a task has finished with the error status.
+var ErrTaskFailed = errors.New("task has finished with the error status")
+
+// Wait waits until a cluster task referenced by its id is finished and returns it.
+// ErrTaskFailed is returned along with the task if the task has finished with the error status.
+// DefaultPollInterval is used if the provided interval is not positive.
+func Wait(ctx context.Context, client *v1.ServiceClient, clusterID, taskID string, interval time.Duration) (*View, error) {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	for {
+		clusterTask, _, err := Get(ctx, client, clusterID, taskID)
+		if err != nil {
+			return nil, err
+		}
+		switch clusterTask.Status {
+		case StatusDone:
+			return clusterTask, nil
+		case StatusError:
+			return clusterTask, wrapTaskFailed(clusterTask)
+		case StatusInProgress, StatusUnknown:
+		}
+
+		if err := v1.Sleep(ctx, interval); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// wrapTaskFailed returns ErrTaskFailed with the task details.
+func wrapTaskFailed(clusterTask *View) error {
+	return fmt.Errorf("%w: %s %s", ErrTaskFailed, clusterTask.Type, clusterTask.ID)
+}
+
+// FindNew returns the first task of the provided type from the current tasks list
+// that is absent in the previous tasks list. Nodegroup id is checked only if it's not empty.
+// Nil is returned if there is no such task.
+func FindNew(previous, current []*View, taskType Type, nodegroupID string) *View {
+	known := make(map[string]struct{}, len(previous))
+	for _, clusterTask := range previous {
+		known[clusterTask.ID] = struct{}{}
+	}
+
+	for _, clusterTask := range current {
+		if _, ok := known[clusterTask.ID]; ok {
+			continue
+		}
+		if clusterTask.Type != taskType {
+			continue
+		}
+		if nodegroupID != "" && clusterTask.NodeGroupID != nodegroupID {
+			continue
+		}
+
+		return clusterTask
+	}
+
+	return nil
+}
+
+// WaitForNew waits until a new task of the provided type that is absent in the previous tasks list
+// appears in the cluster tasks list and returns it. Nodegroup id is checked only if it's not empty.
+// DefaultPollInterval is used if the provided interval is not positive.
+func WaitForNew(ctx context.Context, client *v1.ServiceClient, clusterID string, previous []*View,
+	taskType Type, nodegroupID string, interval time.Duration,
+) (*View, error) {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	for {
+		current, _, err := List(ctx, client, clusterID)
+		if err != nil {
+			return nil, err
+		}
+		if clusterTask := FindNew(previous, current, taskType, nodegroupID); clusterTask != nil {
+			return clusterTask, nil
+		}
+
+		if err := v1.Sleep(ctx, interval); err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package task
+
+import (
+	"context"
+	"time"
+
+	v1 "github.com/selectel/mks-go/pkg/v1"
+)
+
+const (
+	// DefaultWatchMinInterval represents the default interval between polls while tasks are in progress.
+	DefaultWatchMinInterval = 5 * time.Second
+
+	// DefaultWatchMaxInterval represents the default maximum interval between polls of an idle cluster.
+	DefaultWatchMaxInterval = time.Minute
+)
+
+// EventType represents custom type for task watch events.
+type EventType string
+
+const (
+	// EventTaskStarted is sent when a new task appears in the tasks list.
+	EventTaskStarted EventType = "TASK_STARTED"
+
+	// EventTaskStatusChanged is sent when a task changes its status but isn't finished.
+	EventTaskStatusChanged EventType = "TASK_STATUS_CHANGED"
+
+	// EventTaskFinished is sent when a task gets the done or the error status.
+	EventTaskFinished EventType = "TASK_FINISHED"
+
+	// EventError is sent when tasks can't be listed. Watching is continued after the error.
+	EventError EventType = "ERROR"
+)
+
+// Event represents a change of cluster tasks.
+type Event struct {
+	// Type represents the type of the event.
+	Type EventType
+
+	// Task represents the task state. It isn't set for EventError events.
+	Task *View
+
+	// PreviousStatus contains the task status before the change.
+	// It's set for EventTaskStatusChanged and EventTaskFinished events of known tasks.
+	PreviousStatus Status
+
+	// Err contains the error of EventError events.
+	Err error
+}
+
+// WatchOpts represents options for the Watch function.
+type WatchOpts struct {
+	// MinInterval represents the interval between polls while tasks are in progress
+	// or after a change. DefaultWatchMinInterval is used if it's not set.
+	MinInterval time.Duration
+
+	// MaxInterval represents the maximum interval between polls. The interval is doubled
+	// after each poll without changes and after errors up to this value.
+	// DefaultWatchMaxInterval is used if it's not set.
+	MaxInterval time.Duration
+
+	// IncludeExisting sends EventTaskStarted events for tasks that are in progress
+	// at the first poll. Only later changes are sent by default.
+	IncludeExisting bool
+}
+
+// Watch polls the cluster tasks list and sends events about started, changed and finished tasks.
+// The returned channel is closed after the context is done and only then, errors of single polls
+// are sent as EventError events. The channel should be read until it's closed.
+func Watch(ctx context.Context, client *v1.ServiceClient, clusterID string, opts *WatchOpts) <-chan Event {
+	w := &watcher{
+		client:    client,
+		clusterID: clusterID,
+		events:    make(chan Event),
+	}
+	if opts != nil {
+		w.opts = *opts
+	}
+	if w.opts.MinInterval <= 0 {
+		w.opts.MinInterval = DefaultWatchMinInterval
+	}
+	if w.opts.MaxInterval < w.opts.MinInterval {
+		w.opts.MaxInterval = DefaultWatchMaxInterval
+		if w.opts.MaxInterval < w.opts.MinInterval {
+			w.opts.MaxInterval = w.opts.MinInterval
+		}
+	}
+
+	go w.run(ctx)
+
+	return w.events
+}
+
+// watcher contains the state of a single Watch call.
+type watcher struct {
+	client    *v1.ServiceClient
+	clusterID string
+	opts      WatchOpts
+	events    chan Event
+
+	// statuses contains known task statuses, it's nil before the first successful poll.
+	statuses map[string]Status
+}
+
+func (w *watcher) run(ctx context.Context) {
+	defer close(w.events)
+
+	interval := w.opts.MinInterval
+	for {
+		active, err := w.poll(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		switch {
+		case err != nil:
+			w.send(ctx, Event{Type: EventError, Err: err})
+			interval = w.nextInterval(interval)
+		case active:
+			interval = w.opts.MinInterval
+		default:
+			interval = w.nextInterval(interval)
+		}
+
+		if v1.Sleep(ctx, interval) != nil {
+			return
+		}
+	}
+}
+
+// poll lists tasks and sends events. It returns true if there are changes or tasks in progress.
+func (w *watcher) poll(ctx context.Context) (bool, error) {
+	tasks, _, err := List(ctx, w.client, w.clusterID)
+	if err != nil {
+		return false, err
+	}
+
+	firstPoll := w.statuses == nil
+	previous := w.statuses
+	w.statuses = make(map[string]Status, len(tasks))
+
+	active := false
+	for _, clusterTask := range tasks {
+		w.statuses[clusterTask.ID] = clusterTask.Status
+		if clusterTask.Status.IsPending() {
+			active = true
+		}
+
+		if firstPoll {
+			if w.opts.IncludeExisting && clusterTask.Status.IsPending() {
+				w.send(ctx, Event{Type: EventTaskStarted, Task: clusterTask})
+			}
+
+			continue
+		}
+		if w.diff(ctx, clusterTask, previous) {
+			active = true
+		}
+	}
+
+	return active, nil
+}
+
+// diff sends events for the task comparing it with the previous statuses.
+// It returns true if the task has been changed.
+func (w *watcher) diff(ctx context.Context, clusterTask *View, previous map[string]Status) bool {
+	previousStatus, known := previous[clusterTask.ID]
+	switch {
+	case !known:
+		w.send(ctx, Event{Type: EventTaskStarted, Task: clusterTask})
+		if clusterTask.Status.IsTerminal() {
+			w.send(ctx, Event{Type: EventTaskFinished, Task: clusterTask})
+		}
+	case previousStatus == clusterTask.Status:
+		return false
+	case clusterTask.Status.IsTerminal():
+		w.send(ctx, Event{Type: EventTaskFinished, Task: clusterTask, PreviousStatus: previousStatus})
+	default:
+		w.send(ctx, Event{Type: EventTaskStatusChanged, Task: clusterTask, PreviousStatus: previousStatus})
+	}
+
+	return true
+}
+
+func (w *watcher) nextInterval(interval time.Duration) time.Duration {
+	interval *= 2
+	if interval > w.opts.MaxInterval {
+		return w.opts.MaxInterval
+	}
+
+	return interval
+}
+
+func (w *watcher) send(ctx context.Context, event Event) {
+	select {
+	case w.events <- event:
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,47 @@
+package v1
+
+import "sync"
+
+// UnknownValueKind represents custom type for kinds of API values that can be unknown to the client.
+type UnknownValueKind string
+
+const (
+	UnknownValueClusterStatus   UnknownValueKind = "cluster status"
+	UnknownValueNodegroupStatus UnknownValueKind = "nodegroup status"
+	UnknownValueTaskStatus      UnknownValueKind = "task status"
+	UnknownValueTaskType        UnknownValueKind = "task type"
+)
+
+// UnknownValueHandler is called when the API returns a value that isn't supported by the client.
+// It can be used to log or count new API values.
+type UnknownValueHandler func(kind UnknownValueKind, value string)
+
+var (
+	unknownValueHandlerMu sync.RWMutex
+	unknownValueHandler   UnknownValueHandler
+)
+
+// SetUnknownValueHandler sets the handler that is called for every unknown value
+// found in API responses. Nil handler disables the reporting.
+func SetUnknownValueHandler(handler UnknownValueHandler) {
+	unknownValueHandlerMu.Lock()
+	defer unknownValueHandlerMu.Unlock()
+
+	unknownValueHandler = handler
+}
+
+// ReportUnknownValue calls the handler set with SetUnknownValueHandler.
+// Empty values are not reported.
+func ReportUnknownValue(kind UnknownValueKind, value string) {
+	if value == "" {
+		return
+	}
+
+	unknownValueHandlerMu.RLock()
+	handler := unknownValueHandler
+	unknownValueHandlerMu.RUnlock()
+
+	if handler != nil {
+		handler(kind, value)
+	}
+}
@@ -0,0 +1,28 @@
+package v1
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReportUnknownValue(t *testing.T) {
+	var reported []string
+	SetUnknownValueHandler(func(kind UnknownValueKind, value string) {
+		reported = append(reported, string(kind)+"="+value)
+	})
+	defer SetUnknownValueHandler(nil)
+
+	ReportUnknownValue(UnknownValueTaskType, "NEW_TASK")
+	ReportUnknownValue(UnknownValueTaskStatus, "")
+
+	expected := []string{"task type=NEW_TASK"}
+	if !reflect.DeepEqual(expected, reported) {
+		t.Fatalf("expected %v, but got %v", expected, reported)
+	}
+
+	SetUnknownValueHandler(nil)
+	ReportUnknownValue(UnknownValueClusterStatus, "NEW_STATUS")
+	if len(reported) != 1 {
+		t.Fatalf("expected no reports without a handler, but got %v", reported)
+	}
+}
@@ -0,0 +1,271 @@
+package userdata
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/selectel/mks-go/pkg/v1/nodegroup"
+)
+
+const (
+	// sysctlPath represents the path of the file with sysctls added by the builder.
+	sysctlPath = "/etc/sysctl.d/99-mks-userdata.conf"
+
+	// cloudConfigHeader is the first line of every cloud-config part.
+	cloudConfigHeader = "#cloud-config\n"
+
+	// mergeType makes cloud-init append lists of several cloud-config parts
+	// instead of replacing them.
+	mergeType = "list(append)+dict(recurse_array)+str()"
+)
+
+var (
+	// ErrTooLarge is returned when the encoded user data exceeds MaxSize.
+	ErrTooLarge = errors.New("user data is too large")
+
+	// ErrInvalidCloudConfig is matched by CloudConfigError.
+	ErrInvalidCloudConfig = errors.New("invalid cloud-config")
+)
+
+// CloudConfigError is returned when a cloud-config part isn't a valid YAML document.
+// It matches ErrInvalidCloudConfig and unwraps to the YAML error.
+type CloudConfigError struct {
+	// Filename represents the filename of the invalid part.
+	Filename string
+
+	// Err contains the YAML error.
+	Err error
+}
+
+func (e *CloudConfigError) Error() string {
+	return fmt.Sprintf("%v: %s: %v", ErrInvalidCloudConfig, e.Filename, e.Err)
+}
+
+// Unwrap returns the YAML error.
+func (e *CloudConfigError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports if the error matches ErrInvalidCloudConfig.
+func (e *CloudConfigError) Is(target error) bool {
+	return target == ErrInvalidCloudConfig
+}
+
+// Builder composes cloud-config and shell script parts into MIME multipart user data.
+// Files, sysctls and commands are collected into a generated cloud-config part
+// that is placed before other parts.
+type Builder struct {
+	files    []File
+	sysctls  map[string]string
+	commands []string
+	parts    []Part
+}
+
+// NewBuilder returns an empty user data builder.
+func NewBuilder() *Builder {
+	return &Builder{
+		sysctls: make(map[string]string),
+	}
+}
+
+// WriteFile adds a file written on nodes.
+func (b *Builder) WriteFile(file File) *Builder {
+	b.files = append(b.files, file)
+
+	return b
+}
+
+// AddSysctl adds a kernel parameter that is applied on nodes.
+func (b *Builder) AddSysctl(key, value string) *Builder {
+	b.sysctls[key] = value
+
+	return b
+}
+
+// RunCommand adds a shell command that is run on nodes after files are written.
+func (b *Builder) RunCommand(command string) *Builder {
+	b.commands = append(b.commands, command)
+
+	return b
+}
+
+// AddCloudConfig adds a cloud-config part. The "#cloud-config" header is added if it's missing.
+func (b *Builder) AddCloudConfig(filename, cloudConfig string) *Builder {
+	if !strings.HasPrefix(cloudConfig, strings.TrimSpace(cloudConfigHeader)) {
+		cloudConfig = cloudConfigHeader + cloudConfig
+	}
+	b.parts = append(b.parts, Part{
+		ContentType: ContentTypeCloudConfig,
+		Filename:    filename,
+		Content:     cloudConfig,
+	})
+
+	return b
+}
+
+// AddScript adds a shell script part.
+func (b *Builder) AddScript(filename, script string) *Builder {
+	b.parts = append(b.parts, Part{
+		ContentType: ContentTypeShellScript,
+		Filename:    filename,
+		Content:     script,
+	})
+
+	return b
+}
+
+// Parts returns all parts of the user data including the generated cloud-config part.
+func (b *Builder) Parts() ([]Part, error) {
+	var parts []Part
+	if len(b.files) > 0 || len(b.sysctls) > 0 || len(b.commands) > 0 {
+		generated, err := b.cloudConfig()
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, Part{
+			ContentType: ContentTypeCloudConfig,
+			Filename:    "mks-userdata.yaml",
+			Content:     generated,
+		})
+	}
+	for _, part := range b.parts {
+		if part.ContentType == ContentTypeCloudConfig {
+			var document interface{}
+			if err := yaml.Unmarshal([]byte(part.Content), &document); err != nil {
+				return nil, &CloudConfigError{Filename: part.Filename, Err: err}
+			}
+		}
+		parts = append(parts, part)
+	}
+
+	return parts, nil
+}
+
+// cloudConfig returns the generated cloud-config part with files, sysctls and commands.
+func (b *Builder) cloudConfig() (string, error) {
+	var config struct {
+		WriteFiles []File   `yaml:"write_files,omitempty"`
+		RunCmd     []string `yaml:"runcmd,omitempty"`
+	}
+	config.WriteFiles = append(config.WriteFiles, b.files...)
+	if len(b.sysctls) > 0 {
+		keys := make([]string, 0, len(b.sysctls))
+		for key := range b.sysctls {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		var content strings.Builder
+		for _, key := range keys {
+			fmt.Fprintf(&content, "%s = %s\n", key, b.sysctls[key])
+		}
+		config.WriteFiles = append(config.WriteFiles, File{
+			Path:        sysctlPath,
+			Content:     content.String(),
+			Permissions: "0644",
+		})
+		config.RunCmd = append(config.RunCmd, "sysctl --system")
+	}
+	config.RunCmd = append(config.RunCmd, b.commands...)
+
+	var buf bytes.Buffer
+	buf.WriteString(cloudConfigHeader)
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&config); err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// Build returns the user data in the MIME multipart format. The boundary is derived
+// from the parts, so the same parts always produce the same user data.
+func (b *Builder) Build() ([]byte, error) {
+	parts, err := b.Parts()
+	if err != nil {
+		return nil, err
+	}
+
+	return buildMultipart(parts)
+}
+
+// Encode returns the base64 encoded user data that can be used in nodegroup.CreateOpts.
+// ErrTooLarge is returned if the encoded user data exceeds MaxSize.
+func (b *Builder) Encode() (string, error) {
+	data, err := b.Build()
+	if err != nil {
+		return "", err
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(data)
+	if len(encoded) > MaxSize {
+		return "", fmt.Errorf("%w: %d bytes exceed %d bytes", ErrTooLarge, len(encoded), MaxSize)
+	}
+
+	return encoded, nil
+}
+
+// ApplyToCreateOpts sets the encoded user data to the nodegroup Create request options.
+func (b *Builder) ApplyToCreateOpts(opts *nodegroup.CreateOpts) error {
+	encoded, err := b.Encode()
+	if err != nil {
+		return err
+	}
+	opts.UserData = encoded
+
+	return nil
+}
+
+func buildMultipart(parts []Part) ([]byte, error) {
+	hash := sha256.New()
+	for _, part := range parts {
+		fmt.Fprintf(hash, "%s\x00%s\x00%s\x00", part.ContentType, part.Filename, part.Content)
+	}
+	boundary := fmt.Sprintf("MKS-USERDATA-%x", hash.Sum(nil)[:12])
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if err := writer.SetBoundary(boundary); err != nil {
+		return nil, err
+	}
+	for _, part := range parts {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", part.ContentType+`; charset="utf-8"`)
+		header.Set("MIME-Version", "1.0")
+		if part.Filename != "" {
+			header.Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, part.Filename))
+		}
+		if part.ContentType == ContentTypeCloudConfig {
+			header.Set("Merge-Type", mergeType)
+		}
+		partWriter, err := writer.CreatePart(header)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := partWriter.Write([]byte(part.Content)); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	var data bytes.Buffer
+	fmt.Fprintf(&data, "Content-Type: multipart/mixed; boundary=\"%s\"\r\nMIME-Version: 1.0\r\n\r\n", boundary)
+	data.Write(body.Bytes())
+
+	return data.Bytes(), nil
+}
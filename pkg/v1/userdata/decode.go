@@ -0,0 +1,112 @@
+package userdata
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"reflect"
+	"strings"
+)
+
+// Decode decodes base64 user data, for example GetView.UserData, into its parts.
+// User data that isn't MIME multipart is returned as a single part with the content type
+// detected from its first line.
+func Decode(userData string) ([]Part, error) {
+	data, err := base64.StdEncoding.DecodeString(userData)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(data)
+}
+
+// Parse splits raw user data into its parts.
+func Parse(data []byte) ([]Part, error) {
+	reader := textproto.NewReader(bufio.NewReader(bytes.NewReader(data)))
+	header, err := reader.ReadMIMEHeader()
+	if err != nil && !errors.Is(err, io.EOF) {
+		return []Part{singlePart(data)}, nil
+	}
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") {
+		return []Part{singlePart(data)}, nil
+	}
+
+	var parts []Part
+	multipartReader := multipart.NewReader(reader.R, params["boundary"])
+	for {
+		mimePart, err := multipartReader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return parts, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		part, err := readPart(mimePart)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+	}
+}
+
+// readPart reads a single MIME part and decodes its body if it's base64 encoded.
+func readPart(mimePart *multipart.Part) (Part, error) {
+	content, err := io.ReadAll(mimePart)
+	if err != nil {
+		return Part{}, err
+	}
+	if strings.EqualFold(mimePart.Header.Get("Content-Transfer-Encoding"), "base64") {
+		content, err = base64.StdEncoding.DecodeString(strings.Join(strings.Fields(string(content)), ""))
+		if err != nil {
+			return Part{}, err
+		}
+	}
+
+	contentType, _, err := mime.ParseMediaType(mimePart.Header.Get("Content-Type"))
+	if err != nil {
+		contentType = ContentTypePlain
+	}
+
+	return Part{
+		ContentType: contentType,
+		Filename:    mimePart.FileName(),
+		Content:     string(content),
+	}, nil
+}
+
+// singlePart returns user data that isn't MIME multipart as a part.
+func singlePart(data []byte) Part {
+	content := string(data)
+	contentType := ContentTypePlain
+	switch {
+	case strings.HasPrefix(content, strings.TrimSpace(cloudConfigHeader)):
+		contentType = ContentTypeCloudConfig
+	case strings.HasPrefix(content, "#!"):
+		contentType = ContentTypeShellScript
+	}
+
+	return Part{
+		ContentType: contentType,
+		Content:     content,
+	}
+}
+
+// Equal returns true if both base64 user data contain the same parts.
+func Equal(a, b string) (bool, error) {
+	partsA, err := Decode(a)
+	if err != nil {
+		return false, err
+	}
+	partsB, err := Decode(b)
+	if err != nil {
+		return false, err
+	}
+
+	return reflect.DeepEqual(partsA, partsB), nil
+}
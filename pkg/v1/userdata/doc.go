@@ -0,0 +1,38 @@
+/*
+Package userdata provides the ability to build cloud-init user data for nodegroup nodes
+and to decode user data of existing nodegroups.
+
+Example of building user data for a new nodegroup
+
+	builder := userdata.NewBuilder().
+	  WriteFile(userdata.File{
+	    Path:        "/etc/motd",
+	    Content:     "Managed by MKS\n",
+	    Permissions: "0644",
+	  }).
+	  AddSysctl("vm.max_map_count", "262144").
+	  RunCommand("systemctl restart systemd-journald").
+	  AddScript("install-tools.sh", "#!/bin/bash\napt-get install -y htop\n")
+	createOpts := &nodegroup.CreateOpts{
+	  Count:    3,
+	  FlavorID: flavorID,
+	}
+	if err := builder.ApplyToCreateOpts(createOpts); err != nil {
+	  log.Fatal(err)
+	}
+
+Example of inspecting user data of a cluster nodegroup
+
+	mksNodegroup, _, err := nodegroup.Get(ctx, mksClient, clusterID, nodegroupID)
+	if err != nil {
+	  log.Fatal(err)
+	}
+	parts, err := userdata.Decode(mksNodegroup.UserData)
+	if err != nil {
+	  log.Fatal(err)
+	}
+	for _, part := range parts {
+	  fmt.Println(part.String())
+	}
+*/
+package userdata
@@ -0,0 +1,49 @@
+package userdata
+
+import "fmt"
+
+const (
+	// ContentTypeCloudConfig represents the content type of cloud-config parts.
+	ContentTypeCloudConfig = "text/cloud-config"
+
+	// ContentTypeShellScript represents the content type of shell script parts.
+	ContentTypeShellScript = "text/x-shellscript"
+
+	// ContentTypePlain represents the content type of parts of an unknown format.
+	ContentTypePlain = "text/plain"
+)
+
+// MaxSize represents the maximum size of the base64 encoded user data accepted by the API.
+const MaxSize = 65535
+
+// Part represents a single part of the user data.
+type Part struct {
+	// ContentType represents the MIME type of the part.
+	ContentType string
+
+	// Filename represents the file name of the part. It can be empty.
+	Filename string
+
+	// Content contains the part body.
+	Content string
+}
+
+// String returns the part with its type and file name.
+func (part *Part) String() string {
+	return fmt.Sprintf("--- %s %s\n%s", part.ContentType, part.Filename, part.Content)
+}
+
+// File represents a file written on nodes.
+type File struct {
+	// Path represents the absolute path of the file.
+	Path string `yaml:"path"`
+
+	// Content contains the file content.
+	Content string `yaml:"content"`
+
+	// Permissions represents file permissions in the octal form, for example "0644".
+	Permissions string `yaml:"permissions,omitempty"`
+
+	// Owner represents the file owner in the "user:group" form.
+	Owner string `yaml:"owner,omitempty"`
+}
@@ -0,0 +1,51 @@
+package testing
+
+import "github.com/selectel/mks-go/pkg/v1/userdata"
+
+// testCloudConfig represents a cloud-config added to the builder.
+const testCloudConfig = `packages:
+  - htop
+`
+
+// testScript represents a shell script added to the builder.
+const testScript = `#!/bin/bash
+echo "node is ready"
+`
+
+// expectedGeneratedCloudConfig represents the cloud-config generated from files, sysctls and commands.
+const expectedGeneratedCloudConfig = `#cloud-config
+write_files:
+  - path: /etc/motd
+    content: |
+      Managed by MKS
+    permissions: "0644"
+  - path: /etc/sysctl.d/99-mks-userdata.conf
+    content: |
+      net.core.somaxconn = 1024
+      vm.max_map_count = 262144
+    permissions: "0644"
+runcmd:
+  - sysctl --system
+  - systemctl restart systemd-journald
+`
+
+var expectedParts = []userdata.Part{
+	{
+		ContentType: userdata.ContentTypeCloudConfig,
+		Filename:    "mks-userdata.yaml",
+		Content:     expectedGeneratedCloudConfig,
+	},
+	{
+		ContentType: userdata.ContentTypeCloudConfig,
+		Filename:    "packages.yaml",
+		Content:     "#cloud-config\n" + testCloudConfig,
+	},
+	{
+		ContentType: userdata.ContentTypeShellScript,
+		Filename:    "ready.sh",
+		Content:     testScript,
+	},
+}
+
+// testBase64ScriptUserData represents user data of a nodegroup with a plain shell script.
+const testBase64ScriptUserData = "IyEvYmluL2Jhc2ggLXYKYXB0IC15IHVwZGF0ZQphcHQgLXkgaW5zdGFsbCBtdHI="
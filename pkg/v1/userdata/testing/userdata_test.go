@@ -0,0 +1,127 @@
+package testing
+
+import (
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/selectel/mks-go/pkg/v1/nodegroup"
+	"github.com/selectel/mks-go/pkg/v1/userdata"
+)
+
+func newTestBuilder() *userdata.Builder {
+	return userdata.NewBuilder().
+		WriteFile(userdata.File{Path: "/etc/motd", Content: "Managed by MKS\n", Permissions: "0644"}).
+		AddSysctl("vm.max_map_count", "262144").
+		AddSysctl("net.core.somaxconn", "1024").
+		RunCommand("systemctl restart systemd-journald").
+		AddCloudConfig("packages.yaml", testCloudConfig).
+		AddScript("ready.sh", testScript)
+}
+
+func TestBuildAndDecode(t *testing.T) {
+	parts, err := newTestBuilder().Parts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expectedParts, parts) {
+		t.Fatalf("expected %#v, but got %#v", expectedParts, parts)
+	}
+
+	opts := &nodegroup.CreateOpts{}
+	if err := newTestBuilder().ApplyToCreateOpts(opts); err != nil {
+		t.Fatal(err)
+	}
+	raw, err := base64.StdEncoding.DecodeString(opts.UserData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(raw), "Content-Type: multipart/mixed;") ||
+		!strings.Contains(string(raw), "Merge-Type: list(append)+dict(recurse_array)+str()") {
+		t.Fatalf("expected MIME multipart user data, but got %s", raw)
+	}
+
+	decoded, err := userdata.Decode(opts.UserData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expectedParts, decoded) {
+		t.Fatalf("expected %#v, but got %#v", expectedParts, decoded)
+	}
+}
+
+func TestEncodeIsDeterministic(t *testing.T) {
+	first, err := newTestBuilder().Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := newTestBuilder().Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != second {
+		t.Fatal("expected the same user data for the same parts")
+	}
+
+	changed, err := newTestBuilder().RunCommand("reboot").Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	equal, err := userdata.Equal(first, changed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if equal {
+		t.Fatal("expected user data with different commands to differ")
+	}
+}
+
+func TestEncodeTooLarge(t *testing.T) {
+	builder := userdata.NewBuilder().AddScript("large.sh", "#!/bin/sh\n"+strings.Repeat("#", userdata.MaxSize))
+
+	_, err := builder.Encode()
+	if !errors.Is(err, userdata.ErrTooLarge) {
+		t.Fatalf("expected ErrTooLarge, but got %v", err)
+	}
+}
+
+func TestInvalidCloudConfig(t *testing.T) {
+	builder := userdata.NewBuilder().AddCloudConfig("invalid.yaml", "packages: [htop")
+
+	_, err := builder.Encode()
+	if !errors.Is(err, userdata.ErrInvalidCloudConfig) {
+		t.Fatalf("expected ErrInvalidCloudConfig, but got %v", err)
+	}
+	var cloudConfigErr *userdata.CloudConfigError
+	if !errors.As(err, &cloudConfigErr) || cloudConfigErr.Filename != "invalid.yaml" || cloudConfigErr.Err == nil {
+		t.Fatalf("expected CloudConfigError of invalid.yaml with the YAML error, but got %#v", err)
+	}
+}
+
+func TestDecodeSinglePart(t *testing.T) {
+	parts, err := userdata.Decode(testBase64ScriptUserData)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []userdata.Part{
+		{
+			ContentType: userdata.ContentTypeShellScript,
+			Content:     "#!/bin/bash -v\napt -y update\napt -y install mtr",
+		},
+	}
+	if !reflect.DeepEqual(expected, parts) {
+		t.Fatalf("expected %#v, but got %#v", expected, parts)
+	}
+
+	cloudConfig := base64.StdEncoding.EncodeToString([]byte("#cloud-config\n" + testCloudConfig))
+	parts, err = userdata.Decode(cloudConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parts) != 1 || parts[0].ContentType != userdata.ContentTypeCloudConfig {
+		t.Fatalf("expected a single cloud-config part, but got %#v", parts)
+	}
+}
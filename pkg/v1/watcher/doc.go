@@ -0,0 +1,34 @@
+/*
+Package watcher provides the ability to watch changes of clusters, nodegroups and nodes
+through the MKS V1 API.
+
+Example of watching all clusters of the project and sending events to a webhook
+
+	fleetWatcher := watcher.New(mksClient, &watcher.Opts{
+	  Interval: time.Minute,
+	  Handlers: []watcher.Handler{
+	    watcher.NewLogHandler(nil),
+	    watcher.NewWebhook("https://alerts.example.org/mks"),
+	  },
+	  ErrorHandler: func(err error) {
+	    log.Println(err)
+	  },
+	})
+	if err := fleetWatcher.Run(ctx); err != nil {
+	  log.Println(err)
+	}
+
+Example of polling selected clusters once
+
+	fleetWatcher := watcher.New(mksClient, &watcher.Opts{
+	  ClusterIDs: []string{clusterID},
+	})
+	events, err := fleetWatcher.Poll(ctx)
+	if err != nil {
+	  log.Fatal(err)
+	}
+	for _, event := range events {
+	  fmt.Printf("%+v\n", event)
+	}
+*/
+package watcher
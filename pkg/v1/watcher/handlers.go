@@ -0,0 +1,95 @@
+package watcher
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/selectel/mks-go/pkg/v1/internal/polling"
+)
+
+// Handler handles fleet watcher events.
+type Handler = polling.Handler[Event]
+
+// HandlerFunc is an adapter to use ordinary functions as handlers.
+type HandlerFunc = polling.HandlerFunc[Event]
+
+// Webhook sends events as JSON bodies of POST requests.
+type Webhook struct {
+	// URL represents the webhook receiver.
+	URL string
+
+	// HTTPClient is used to send requests. http.DefaultClient is used if it's not set.
+	HTTPClient *http.Client
+
+	// Headers contains additional request headers, for example an authorization token.
+	Headers map[string]string
+}
+
+// NewWebhook returns a webhook handler that sends events to the provided URL.
+func NewWebhook(url string) *Webhook {
+	return &Webhook{
+		URL: url,
+	}
+}
+
+// Handle sends the event. Responses with a non 2xx status code are treated as errors.
+func (webhook *Webhook) Handle(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	for name, value := range webhook.Headers {
+		request.Header.Set(name, value)
+	}
+
+	httpClient := webhook.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook %s responded with the %d status code", webhook.URL, response.StatusCode)
+	}
+
+	return nil
+}
+
+// LogHandler writes events into a logger.
+type LogHandler struct {
+	logger *log.Logger
+}
+
+// NewLogHandler returns a handler that writes events into the provided logger.
+// The standard logger is used if the logger is nil.
+func NewLogHandler(logger *log.Logger) *LogHandler {
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	return &LogHandler{
+		logger: logger,
+	}
+}
+
+// Handle writes the event.
+func (handler *LogHandler) Handle(_ context.Context, event Event) error {
+	handler.logger.Printf("%s cluster=%s nodegroup=%s node=%s previous=%q current=%q",
+		event.Type, event.ClusterID, event.NodegroupID, event.NodeID, event.Previous, event.Current)
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package watcher
+
+import "time"
+
+// EventType represents custom type for fleet watcher events.
+type EventType string
+
+const (
+	EventClusterAdded              EventType = "CLUSTER_ADDED"
+	EventClusterRemoved            EventType = "CLUSTER_REMOVED"
+	EventClusterStatusChanged      EventType = "CLUSTER_STATUS_CHANGED"
+	EventClusterKubeVersionChanged EventType = "CLUSTER_KUBE_VERSION_CHANGED"
+	EventNodegroupAdded            EventType = "NODEGROUP_ADDED"
+	EventNodegroupRemoved          EventType = "NODEGROUP_REMOVED"
+	EventNodegroupStatusChanged    EventType = "NODEGROUP_STATUS_CHANGED"
+	EventNodegroupResized          EventType = "NODEGROUP_RESIZED"
+	EventNodeAdded                 EventType = "NODE_ADDED"
+	EventNodeRemoved               EventType = "NODE_REMOVED"
+)
+
+// Event represents a change of a cluster, a nodegroup or a node.
+type Event struct {
+	// Type represents the type of the event.
+	Type EventType `json:"type"`
+
+	// Time represents the time of the poll that has found the change.
+	Time time.Time `json:"time"`
+
+	// ClusterID contains the cluster identifier.
+	ClusterID string `json:"cluster_id"`
+
+	// NodegroupID contains the nodegroup identifier. It's set for nodegroup and node events.
+	NodegroupID string `json:"nodegroup_id,omitempty"`
+
+	// NodeID contains the node identifier. It's set for node events.
+	NodeID string `json:"node_id,omitempty"`
+
+	// Previous contains the previous value of the changed field:
+	// a status, a Kubernetes version or an amount of nodes.
+	Previous string `json:"previous,omitempty"`
+
+	// Current contains the current value of the changed field.
+	Current string `json:"current,omitempty"`
+}
+
+// clusterState represents the watched fields of a cluster.
+type clusterState struct {
+	status      string
+	kubeVersion string
+	nodegroups  map[string]*nodegroupState
+}
+
+// nodegroupState represents the watched fields of a nodegroup.
+type nodegroupState struct {
+	status string
+	nodes  map[string]struct{}
+}
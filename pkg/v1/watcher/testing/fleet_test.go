@@ -0,0 +1,111 @@
+package testing
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// fakeNodegroup represents a nodegroup of the fake fleet.
+type fakeNodegroup struct {
+	ID     string
+	Status string
+	Nodes  []string
+}
+
+// fakeCluster represents a cluster of the fake fleet.
+type fakeCluster struct {
+	ID          string
+	Status      string
+	KubeVersion string
+	Nodegroups  []*fakeNodegroup
+}
+
+// fakeFleet emulates cluster and nodegroup list endpoints of the MKS API.
+type fakeFleet struct {
+	mu       sync.Mutex
+	clusters []*fakeCluster
+}
+
+// update changes the fleet under the lock.
+func (fleet *fakeFleet) update(f func(clusters []*fakeCluster) []*fakeCluster) {
+	fleet.mu.Lock()
+	defer fleet.mu.Unlock()
+
+	fleet.clusters = f(fleet.clusters)
+}
+
+func (fleet *fakeFleet) register(mux *http.ServeMux) {
+	mux.HandleFunc("/v1/clusters", func(w http.ResponseWriter, r *http.Request) {
+		fleet.mu.Lock()
+		defer fleet.mu.Unlock()
+
+		clusters := make([]map[string]interface{}, 0, len(fleet.clusters))
+		for _, c := range fleet.clusters {
+			clusters = append(clusters, map[string]interface{}{
+				"id":           c.ID,
+				"status":       c.Status,
+				"kube_version": c.KubeVersion,
+			})
+		}
+		writeJSON(w, map[string]interface{}{"clusters": clusters})
+	})
+	mux.HandleFunc("/v1/clusters/", func(w http.ResponseWriter, r *http.Request) {
+		fleet.mu.Lock()
+		defer fleet.mu.Unlock()
+
+		clusterID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v1/clusters/"), "/nodegroups")
+		for _, c := range fleet.clusters {
+			if c.ID != clusterID {
+				continue
+			}
+			nodegroups := make([]map[string]interface{}, 0, len(c.Nodegroups))
+			for _, ng := range c.Nodegroups {
+				nodes := make([]map[string]interface{}, 0, len(ng.Nodes))
+				for _, nodeID := range ng.Nodes {
+					nodes = append(nodes, map[string]interface{}{"id": nodeID, "nodegroup_id": ng.ID})
+				}
+				nodegroups = append(nodegroups, map[string]interface{}{
+					"id":         ng.ID,
+					"cluster_id": c.ID,
+					"status":     ng.Status,
+					"nodes":      nodes,
+				})
+			}
+			writeJSON(w, map[string]interface{}{"nodegroups": nodegroups})
+
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, body interface{}) {
+	w.Header().Add("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// newTestFleet returns the initial state of the fake fleet.
+func newTestFleet() *fakeFleet {
+	return &fakeFleet{
+		clusters: []*fakeCluster{
+			{
+				ID:          "cluster-1",
+				Status:      "ACTIVE",
+				KubeVersion: "1.27.8",
+				Nodegroups: []*fakeNodegroup{
+					{ID: "nodegroup-1", Status: "ACTIVE", Nodes: []string{"node-1", "node-2"}},
+				},
+			},
+			{
+				ID:          "cluster-2",
+				Status:      "ACTIVE",
+				KubeVersion: "1.28.5",
+				Nodegroups: []*fakeNodegroup{
+					{ID: "nodegroup-2", Status: "ACTIVE", Nodes: []string{"node-3"}},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,246 @@
+package testing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/selectel/mks-go/pkg/testutils"
+	v1 "github.com/selectel/mks-go/pkg/v1"
+	"github.com/selectel/mks-go/pkg/v1/watcher"
+)
+
+func newTestClient(testEnv *testutils.TestEnv) *v1.ServiceClient {
+	return &v1.ServiceClient{
+		HTTPClient: &http.Client{},
+		TokenID:    testutils.TokenID,
+		Endpoint:   testEnv.Server.URL + "/v1",
+		UserAgent:  testutils.UserAgent,
+	}
+}
+
+// changeTestFleet applies changes that are expected to produce every event type.
+func changeTestFleet(fleet *fakeFleet) {
+	fleet.update(func(clusters []*fakeCluster) []*fakeCluster {
+		first := clusters[0]
+		first.Status = "PENDING_UPGRADE_MINOR_VERSION"
+		first.KubeVersion = "1.28.5"
+		first.Nodegroups[0].Nodes = []string{"node-1", "node-4", "node-5"}
+		first.Nodegroups[0].Status = "PENDING_SCALE_UP"
+		first.Nodegroups = append(first.Nodegroups, &fakeNodegroup{ID: "nodegroup-3", Status: "PENDING_CREATE"})
+
+		return []*fakeCluster{first, {ID: "cluster-3", Status: "PENDING_CREATE", KubeVersion: "1.28.5"}}
+	})
+}
+
+func eventTypes(events []watcher.Event) []string {
+	types := make([]string, 0, len(events))
+	for _, event := range events {
+		description := string(event.Type) + " " + event.ClusterID
+		if event.NodegroupID != "" {
+			description += "/" + event.NodegroupID
+		}
+		if event.NodeID != "" {
+			description += "/" + event.NodeID
+		}
+		if event.Previous != "" || event.Current != "" {
+			description += " " + event.Previous + "->" + event.Current
+		}
+		types = append(types, description)
+	}
+
+	return types
+}
+
+func TestPoll(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	fleet := newTestFleet()
+	fleet.register(testEnv.Mux)
+
+	ctx := context.Background()
+	fleetWatcher := watcher.New(newTestClient(testEnv), nil)
+
+	events, err := fleetWatcher.Poll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 0 {
+		t.Fatalf("expected no events after the first poll, but got %v", eventTypes(events))
+	}
+
+	changeTestFleet(fleet)
+	events, err = fleetWatcher.Poll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"CLUSTER_STATUS_CHANGED cluster-1 ACTIVE->PENDING_UPGRADE_MINOR_VERSION",
+		"CLUSTER_KUBE_VERSION_CHANGED cluster-1 1.27.8->1.28.5",
+		"NODEGROUP_STATUS_CHANGED cluster-1/nodegroup-1 ACTIVE->PENDING_SCALE_UP",
+		"NODEGROUP_RESIZED cluster-1/nodegroup-1 2->3",
+		"NODE_ADDED cluster-1/nodegroup-1/node-4",
+		"NODE_ADDED cluster-1/nodegroup-1/node-5",
+		"NODE_REMOVED cluster-1/nodegroup-1/node-2",
+		"NODEGROUP_ADDED cluster-1/nodegroup-3 ->0",
+		"CLUSTER_ADDED cluster-3 ->PENDING_CREATE",
+		"CLUSTER_REMOVED cluster-2 ACTIVE->",
+	}
+	if actual := eventTypes(events); strings.Join(expected, "\n") != strings.Join(actual, "\n") {
+		t.Fatalf("expected events:\n%s\nbut got:\n%s", strings.Join(expected, "\n"), strings.Join(actual, "\n"))
+	}
+
+	events, err = fleetWatcher.Poll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 0 {
+		t.Fatalf("expected no events without changes, but got %v", eventTypes(events))
+	}
+}
+
+func TestPollClusterIDs(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	fleet := newTestFleet()
+	fleet.register(testEnv.Mux)
+
+	ctx := context.Background()
+	fleetWatcher := watcher.New(newTestClient(testEnv), &watcher.Opts{ClusterIDs: []string{"cluster-2"}})
+
+	if _, err := fleetWatcher.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	changeTestFleet(fleet)
+	events, err := fleetWatcher.Poll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"CLUSTER_REMOVED cluster-2 ACTIVE->"}
+	if actual := eventTypes(events); strings.Join(expected, "\n") != strings.Join(actual, "\n") {
+		t.Fatalf("expected %v events, but got %v", expected, actual)
+	}
+}
+
+func TestWebhookHandler(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received []watcher.Event
+		headers  []string
+	)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event watcher.Event
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, event)
+		headers = append(headers, r.Header.Get("Authorization"))
+		if event.Type == watcher.EventClusterRemoved {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer receiver.Close()
+
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	fleet := newTestFleet()
+	fleet.register(testEnv.Mux)
+
+	webhook := watcher.NewWebhook(receiver.URL)
+	webhook.Headers = map[string]string{"Authorization": "Bearer token"}
+	var handlerErrors []error
+	fleetWatcher := watcher.New(newTestClient(testEnv), &watcher.Opts{
+		Handlers: []watcher.Handler{webhook},
+		ErrorHandler: func(err error) {
+			handlerErrors = append(handlerErrors, err)
+		},
+	})
+
+	ctx := context.Background()
+	if _, err := fleetWatcher.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	changeTestFleet(fleet)
+	events, err := fleetWatcher.Poll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(received) != len(events) {
+		t.Fatalf("expected %d received events, but got %d", len(events), len(received))
+	}
+	for i := range events {
+		if received[i].Type != events[i].Type || received[i].ClusterID != events[i].ClusterID ||
+			!received[i].Time.Equal(events[i].Time) || headers[i] != "Bearer token" {
+			t.Fatalf("expected %#v event, but got %#v", events[i], received[i])
+		}
+	}
+	if len(handlerErrors) != 1 {
+		t.Fatalf("expected a single webhook error, but got %v", handlerErrors)
+	}
+}
+
+func TestLogHandler(t *testing.T) {
+	var buf bytes.Buffer
+	handler := watcher.NewLogHandler(log.New(&buf, "", 0))
+
+	err := handler.Handle(context.Background(), watcher.Event{
+		Type:        watcher.EventNodeRemoved,
+		ClusterID:   "cluster-1",
+		NodegroupID: "nodegroup-1",
+		NodeID:      "node-2",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `NODE_REMOVED cluster=cluster-1 nodegroup=nodegroup-1 node=node-2 previous="" current=""` + "\n"
+	if buf.String() != expected {
+		t.Fatalf("expected %q, but got %q", expected, buf.String())
+	}
+}
+
+func TestRunReportsErrors(t *testing.T) {
+	testEnv := testutils.SetupTestEnv()
+	defer testEnv.TearDownTestEnv()
+	testEnv.Mux.HandleFunc("/v1/clusters", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var pollErrors int
+	fleetWatcher := watcher.New(newTestClient(testEnv), &watcher.Opts{
+		Interval: time.Millisecond,
+		ErrorHandler: func(err error) {
+			pollErrors++
+			if pollErrors == 3 {
+				cancel()
+			}
+		},
+	})
+
+	if err := fleetWatcher.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, but got %v", err)
+	}
+	if pollErrors < 3 {
+		t.Fatalf("expected at least 3 poll errors, but got %d", pollErrors)
+	}
+}
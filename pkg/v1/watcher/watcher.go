@@ -0,0 +1,240 @@
+package watcher
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	v1 "github.com/selectel/mks-go/pkg/v1"
+	"github.com/selectel/mks-go/pkg/v1/cluster"
+	"github.com/selectel/mks-go/pkg/v1/internal/polling"
+	"github.com/selectel/mks-go/pkg/v1/nodegroup"
+)
+
+// DefaultInterval represents the default interval between fleet polls.
+const DefaultInterval = time.Minute
+
+// Opts represents options of the fleet watcher.
+type Opts struct {
+	// ClusterIDs contains identifiers of watched clusters. All clusters of the project
+	// are watched if it's empty.
+	ClusterIDs []string
+
+	// Interval represents the interval between polls. DefaultInterval is used if it's not set.
+	Interval time.Duration
+
+	// Handlers receive every event in order.
+	Handlers []Handler
+
+	// ErrorHandler receives errors of polls and handlers. Errors are ignored if it's not set.
+	ErrorHandler func(err error)
+}
+
+// Watcher polls clusters with their nodegroups and emits events about changes.
+type Watcher struct {
+	client *v1.ServiceClient
+	opts   Opts
+	poller *polling.Poller[Event]
+
+	// clusters contains states of the previous poll, it's nil before the first successful poll.
+	clusters map[string]*clusterState
+}
+
+// New returns a fleet watcher. The first poll only remembers the fleet state.
+func New(client *v1.ServiceClient, opts *Opts) *Watcher {
+	w := &Watcher{
+		client: client,
+	}
+	if opts != nil {
+		w.opts = *opts
+	}
+	if w.opts.Interval <= 0 {
+		w.opts.Interval = DefaultInterval
+	}
+	w.poller = &polling.Poller[Event]{
+		Interval:     w.opts.Interval,
+		Handlers:     w.opts.Handlers,
+		ErrorHandler: w.opts.ErrorHandler,
+	}
+
+	return w
+}
+
+// Run polls the fleet until the context is done and returns the context error.
+func (w *Watcher) Run(ctx context.Context) error {
+	return w.poller.Run(ctx, func(ctx context.Context) error {
+		_, err := w.Poll(ctx)
+
+		return err
+	})
+}
+
+// Poll retrieves the fleet state once, passes changes to the handlers and returns them.
+// The state isn't updated if the poll fails.
+func (w *Watcher) Poll(ctx context.Context) ([]Event, error) {
+	clusters, err := w.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	if w.clusters != nil {
+		events = diffClusters(w.clusters, clusters, time.Now().UTC())
+	}
+	w.clusters = clusters
+	w.poller.Dispatch(ctx, events)
+
+	return events, nil
+}
+
+// fetch retrieves watched clusters with their nodegroups.
+func (w *Watcher) fetch(ctx context.Context) (map[string]*clusterState, error) {
+	clusterViews, _, err := cluster.List(ctx, w.client)
+	if err != nil {
+		return nil, err
+	}
+
+	watched := make(map[string]struct{}, len(w.opts.ClusterIDs))
+	for _, clusterID := range w.opts.ClusterIDs {
+		watched[clusterID] = struct{}{}
+	}
+
+	clusters := make(map[string]*clusterState, len(clusterViews))
+	for _, clusterView := range clusterViews {
+		if _, ok := watched[clusterView.ID]; len(watched) > 0 && !ok {
+			continue
+		}
+		nodegroups, err := w.fetchNodegroups(ctx, clusterView.ID)
+		if err != nil {
+			return nil, err
+		}
+		clusters[clusterView.ID] = &clusterState{
+			status:      clusterView.RawStatus,
+			kubeVersion: clusterView.KubeVersion,
+			nodegroups:  nodegroups,
+		}
+	}
+
+	return clusters, nil
+}
+
+// fetchNodegroups retrieves nodegroups of the cluster. Nodegroups of a cluster that
+// can't be found are treated as absent because the cluster is being deleted.
+func (w *Watcher) fetchNodegroups(ctx context.Context, clusterID string) (map[string]*nodegroupState, error) {
+	nodegroupViews, responseResult, err := nodegroup.List(ctx, w.client, clusterID)
+	if err != nil {
+		if responseResult != nil && responseResult.Response != nil && responseResult.StatusCode == http.StatusNotFound {
+			return map[string]*nodegroupState{}, nil
+		}
+
+		return nil, err
+	}
+
+	nodegroups := make(map[string]*nodegroupState, len(nodegroupViews))
+	for _, nodegroupView := range nodegroupViews {
+		nodes := make(map[string]struct{}, len(nodegroupView.Nodes))
+		for _, nodeView := range nodegroupView.Nodes {
+			nodes[nodeView.ID] = struct{}{}
+		}
+		nodegroups[nodegroupView.ID] = &nodegroupState{
+			status: nodegroupView.RawStatus,
+			nodes:  nodes,
+		}
+	}
+
+	return nodegroups, nil
+}
+
+// diffClusters returns events about changes between the previous and the current fleet states.
+func diffClusters(previous, current map[string]*clusterState, now time.Time) []Event {
+	var events []Event
+	for _, clusterID := range polling.SortedKeys(current) {
+		currentCluster := current[clusterID]
+		previousCluster, ok := previous[clusterID]
+		if !ok {
+			events = append(events, Event{Type: EventClusterAdded, Time: now, ClusterID: clusterID,
+				Current: currentCluster.status})
+
+			continue
+		}
+		if previousCluster.status != currentCluster.status {
+			events = append(events, Event{Type: EventClusterStatusChanged, Time: now, ClusterID: clusterID,
+				Previous: previousCluster.status, Current: currentCluster.status})
+		}
+		if previousCluster.kubeVersion != currentCluster.kubeVersion {
+			events = append(events, Event{Type: EventClusterKubeVersionChanged, Time: now, ClusterID: clusterID,
+				Previous: previousCluster.kubeVersion, Current: currentCluster.kubeVersion})
+		}
+		events = append(events, diffNodegroups(clusterID, previousCluster.nodegroups, currentCluster.nodegroups, now)...)
+	}
+	for _, clusterID := range polling.SortedKeys(previous) {
+		if _, ok := current[clusterID]; !ok {
+			events = append(events, Event{Type: EventClusterRemoved, Time: now, ClusterID: clusterID,
+				Previous: previous[clusterID].status})
+		}
+	}
+
+	return events
+}
+
+// diffNodegroups returns events about changes of cluster nodegroups and their nodes.
+func diffNodegroups(clusterID string, previous, current map[string]*nodegroupState, now time.Time) []Event {
+	var events []Event
+	for _, nodegroupID := range polling.SortedKeys(current) {
+		currentNodegroup := current[nodegroupID]
+		base := Event{Time: now, ClusterID: clusterID, NodegroupID: nodegroupID}
+		previousNodegroup, ok := previous[nodegroupID]
+		if !ok {
+			events = append(events, base.with(EventNodegroupAdded, "", strconv.Itoa(len(currentNodegroup.nodes))))
+
+			continue
+		}
+		if previousNodegroup.status != currentNodegroup.status {
+			events = append(events, base.with(EventNodegroupStatusChanged, previousNodegroup.status, currentNodegroup.status))
+		}
+		if len(previousNodegroup.nodes) != len(currentNodegroup.nodes) {
+			events = append(events, base.with(EventNodegroupResized,
+				strconv.Itoa(len(previousNodegroup.nodes)), strconv.Itoa(len(currentNodegroup.nodes))))
+		}
+		events = append(events, diffNodes(base, previousNodegroup.nodes, currentNodegroup.nodes)...)
+	}
+	for _, nodegroupID := range polling.SortedKeys(previous) {
+		if _, ok := current[nodegroupID]; !ok {
+			events = append(events, Event{Type: EventNodegroupRemoved, Time: now, ClusterID: clusterID,
+				NodegroupID: nodegroupID, Previous: strconv.Itoa(len(previous[nodegroupID].nodes))})
+		}
+	}
+
+	return events
+}
+
+// diffNodes returns events about added and removed nodes of a nodegroup.
+func diffNodes(base Event, previous, current map[string]struct{}) []Event {
+	var events []Event
+	for _, nodeID := range polling.SortedKeys(current) {
+		if _, ok := previous[nodeID]; !ok {
+			event := base.with(EventNodeAdded, "", "")
+			event.NodeID = nodeID
+			events = append(events, event)
+		}
+	}
+	for _, nodeID := range polling.SortedKeys(previous) {
+		if _, ok := current[nodeID]; !ok {
+			event := base.with(EventNodeRemoved, "", "")
+			event.NodeID = nodeID
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
+
+// with returns a copy of the event with the provided type and values.
+func (event Event) with(eventType EventType, previous, current string) Event {
+	event.Type = eventType
+	event.Previous = previous
+	event.Current = current
+
+	return event
+}
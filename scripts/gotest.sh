@@ -11,6 +11,13 @@ for testingpkg in $(go list ./pkg/.../testing ./pkg/v1); do
   fi
   ((i++))
 done
+
+echo "==> Running go test of the drain module..."
+(cd ./pkg/drain && go test -v ./...)
+if [ $? -ne 0 ]; then
+   failed+=1
+fi
+
 gocovmerge $(ls ./*.coverprofile) > coverage.out
 rm *.coverprofile
 